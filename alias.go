@@ -0,0 +1,94 @@
+package goeasyi18n
+
+// maxAliasDepth limits the aliases of aliases
+// to avoid infinite loops with circular aliases
+const maxAliasDepth = 10
+
+// KeyEventType is the type of a KeyEvent
+type KeyEventType string
+
+const (
+	// KeyEventMissing is emitted when a key is not found in any language
+	KeyEventMissing KeyEventType = "missing"
+	// KeyEventDeprecated is emitted when a deprecated key is used
+	KeyEventDeprecated KeyEventType = "deprecated"
+)
+
+// KeyEvent is passed to the OnKeyEvent function of the config
+type KeyEvent struct {
+	Type         KeyEventType
+	LanguageName string
+	Key          string // The key used in the code
+	Context      string
+	ResolvedKey  string // The key after resolving the aliases
+}
+
+// emitKeyEvent calls the OnKeyEvent function if it is set
+func (t *I18n) emitKeyEvent(event KeyEvent) {
+	if t.onKeyEvent != nil {
+		t.onKeyEvent(event)
+	}
+}
+
+// resolveKey follows the aliases of a key (declared with AliasOf in
+// the language or in the fallback language) and reports the usage
+// of the deprecated keys, it returns the key to be used
+func (t *I18n) resolveKey(
+	languageName string,
+	translateKey string,
+	translateContext string,
+) string {
+	requestedKey := translateKey
+	deprecated := false
+
+	for depth := 0; depth < maxAliasDepth; depth++ {
+		aliasOf, isDeprecated := t.findDeclaration(languageName, translateKey, translateContext)
+		deprecated = deprecated || isDeprecated
+		if aliasOf == "" || aliasOf == translateKey {
+			break
+		}
+		translateKey = aliasOf
+	}
+
+	if deprecated {
+		t.emitKeyEvent(KeyEvent{
+			Type:         KeyEventDeprecated,
+			LanguageName: languageName,
+			Key:          requestedKey,
+			Context:      translateContext,
+			ResolvedKey:  translateKey,
+		})
+	}
+
+	return translateKey
+}
+
+// findDeclaration returns the alias of a key and if it is deprecated,
+// the alias is taken from the language if it has the key (so a language
+// can keep its own translation) or from the fallback language, and the
+// key is deprecated if it is deprecated in any of them
+func (t *I18n) findDeclaration(
+	languageName string,
+	translateKey string,
+	translateContext string,
+) (string, bool) {
+	var aliasOf string
+	aliasFound := false
+	deprecated := false
+
+	for _, name := range []string{languageName, t.fallbackLanguageName} {
+		for _, ts := range t.languages[name] {
+			if ts.Key != translateKey || ts.Context != translateContext {
+				continue
+			}
+			deprecated = deprecated || ts.Deprecated
+			if !aliasFound {
+				aliasOf = ts.AliasOf
+				aliasFound = true
+			}
+			break
+		}
+	}
+
+	return aliasOf, deprecated
+}
@@ -0,0 +1,106 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	newI18n := func(config ...Config) *I18n {
+		i18n := NewI18n(config...)
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "welcome", Default: "Welcome"},
+			{Key: "greeting", AliasOf: "welcome", Deprecated: true},
+			{Key: "hello", AliasOf: "greeting"},
+			{Key: "ping", AliasOf: "pong"},
+			{Key: "pong", AliasOf: "ping"},
+			{Key: "old", Default: "Old", Deprecated: true},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "welcome", Default: "Bienvenido"},
+			{Key: "old", Default: "Viejo"},
+		})
+		return i18n
+	}
+
+	t.Run("the aliases should be resolved", func(t *testing.T) {
+		i18n := newI18n()
+
+		tests := []struct {
+			lang     string
+			key      string
+			expected string
+		}{
+			{"en", "greeting", "Welcome"},
+			{"es", "greeting", "Bienvenido"},
+			{"es", "hello", "Bienvenido"},
+			{"es", "ping", ""},
+			{"es", "old", "Viejo"},
+		}
+
+		for _, test := range tests {
+			got := i18n.T(test.lang, test.key)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+	})
+
+	t.Run("a language can keep its own translation of an alias", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.SetTranslation("es", TranslateString{Key: "greeting", Default: "Hola"})
+
+		if got := i18n.T("es", "greeting"); got != "Hola" {
+			t.Errorf("expected Hola; got %s", got)
+		}
+	})
+
+	t.Run("the deprecated and missing keys should be reported", func(t *testing.T) {
+		var events []KeyEvent
+		i18n := newI18n(Config{
+			OnKeyEvent: func(event KeyEvent) {
+				events = append(events, event)
+			},
+		})
+
+		i18n.T("es", "welcome")
+		i18n.T("es", "hello")
+		i18n.T("es", "old")
+		i18n.T("es", "xxx", Options{Context: "menu"})
+
+		expected := []KeyEvent{
+			{Type: KeyEventDeprecated, LanguageName: "es", Key: "hello", ResolvedKey: "welcome"},
+			{Type: KeyEventDeprecated, LanguageName: "es", Key: "old", ResolvedKey: "old"},
+			{Type: KeyEventMissing, LanguageName: "es", Key: "xxx", Context: "menu", ResolvedKey: "xxx"},
+		}
+		if !reflect.DeepEqual(events, expected) {
+			t.Errorf("Unexpected result: %v", events)
+		}
+	})
+
+	t.Run("the aliases should be ignored by the consistency check", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "welcome", Default: "Welcome"},
+			{Key: "greeting", AliasOf: "welcome"},
+		})
+		errors := i18n.AddLanguage("es", TranslateStrings{{Key: "welcome", Default: "Bienvenido"}})
+
+		if len(errors) != 0 {
+			t.Errorf("expected no errors; got %v", errors)
+		}
+	})
+
+	t.Run("the aliases should be loaded", func(t *testing.T) {
+		loaded, err := LoadFromPropertiesString("greeting.AliasOf = welcome\ngreeting.Deprecated = true\n")
+		expected := TranslateStrings{{Key: "greeting", AliasOf: "welcome", Deprecated: true}}
+		if err != nil || !reflect.DeepEqual(loaded, expected) {
+			t.Errorf("Unexpected result: %v %v", loaded, err)
+		}
+
+		_, err = LoadFromPropertiesString("greeting.Deprecated = maybe\n")
+		if err == nil || err.Error() != "goeasyi18n: line 1, column 1: key 'greeting': the field 'Deprecated' must be true or false" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package goeasyi18n
+
+// CatalogFromKeys creates the translations of the source language
+// from literal keys, when the source text is used as the key (see
+// the KeyAsFallback config), every key is also its Default text.
+//
+// The repeated keys are ignored, so the keys extracted from the
+// code can be passed as they are, and the result can be exported
+// with MarshalJson or MarshalYaml to create the catalog files.
+func CatalogFromKeys(keys ...string) TranslateStrings {
+	catalog := make(TranslateStrings, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		catalog = append(catalog, TranslateString{Key: key, Default: key})
+	}
+
+	return catalog
+}
@@ -0,0 +1,20 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalogFromKeys(t *testing.T) {
+	t.Run("should create a translation per key", func(t *testing.T) {
+		got := CatalogFromKeys("You have no new messages", "Hello {{.Name}}", "", "You have no new messages")
+		expected := TranslateStrings{
+			{Key: "You have no new messages", Default: "You have no new messages"},
+			{Key: "Hello {{.Name}}", Default: "Hello {{.Name}}"},
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+}
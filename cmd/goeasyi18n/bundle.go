@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["bundle"] = command{
+		description: "generate a Go file with the translations and a function that registers them",
+		run:         runBundle,
+	}
+}
+
+func runBundle(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("bundle", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	packageName := flags.String("pkg", "translations", "name of the generated package")
+	output := flags.String("out", "", "file to write the generated code (default: stdout)")
+	languages := flags.String("lang", "", "comma separated languages to bundle (default: all)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	i18n, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	languageNames := i18n.LanguageNames()
+	if *languages != "" {
+		languageNames = splitList(*languages)
+	}
+	for _, languageName := range languageNames {
+		if !i18n.HasLanguage(languageName) {
+			fmt.Fprintf(stderr, "goeasyi18n: the language '%s' doesn't exist in %s\n", languageName, *dir)
+			return 1
+		}
+		if i18n.HasFluentLanguage(languageName) {
+			fmt.Fprintf(stderr, "goeasyi18n: the language '%s' has Fluent messages that can't be bundled, use -lang to bundle the other languages\n", languageName)
+			return 1
+		}
+	}
+
+	code, err := generateBundle(i18n, languageNames, *packageName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *output == "" {
+		stdout.Write(code)
+		return 0
+	}
+	if err := os.WriteFile(*output, code, 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// generateBundle generates the Go package with the translations of
+// the languages as TranslateStrings literals, so they don't need to
+// be loaded or parsed at runtime, and the Register function
+func generateBundle(
+	i18n *goeasyi18n.I18n,
+	languageNames []string,
+	packageName string,
+) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by goeasyi18n bundle; DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	fmt.Fprintln(&b, `import "github.com/eduardolat/goeasyi18n"`)
+	fmt.Fprintln(&b)
+
+	quotedNames := make([]string, len(languageNames))
+	for i, languageName := range languageNames {
+		quotedNames[i] = strconv.Quote(languageName)
+	}
+	fmt.Fprintln(&b, "// languageNames are the bundled languages, in the order they are registered")
+	fmt.Fprintf(&b, "var languageNames = []string{%s}\n", strings.Join(quotedNames, ", "))
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "// languages are the translations of the bundled languages")
+	fmt.Fprintln(&b, "var languages = map[string]goeasyi18n.TranslateStrings{")
+	for _, languageName := range languageNames {
+		fmt.Fprintf(&b, "	%q: {\n", languageName)
+		for _, ts := range i18n.Translations(languageName) {
+			fmt.Fprintf(&b, "		{%s},\n", translateStringLiteral(ts))
+		}
+		fmt.Fprintln(&b, "	},")
+	}
+	fmt.Fprintln(&b, "}")
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "// Register adds a copy of the bundled languages to the i18n object,")
+	fmt.Fprintln(&b, "// the pluralization functions can be set after registering them")
+	fmt.Fprintln(&b, "func Register(i18n *goeasyi18n.I18n) {")
+	fmt.Fprintln(&b, "	for _, languageName := range languageNames {")
+	fmt.Fprintln(&b, "		translateStrings := append(goeasyi18n.TranslateStrings{}, languages[languageName]...)")
+	fmt.Fprintln(&b, "		i18n.AddLanguage(languageName, translateStrings)")
+	fmt.Fprintln(&b, "	}")
+	fmt.Fprintln(&b, "}")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// LanguageNames returns the names of the bundled languages")
+	fmt.Fprintln(&b, "func LanguageNames() []string {")
+	fmt.Fprintln(&b, "	return append([]string{}, languageNames...)")
+	fmt.Fprintln(&b, "}")
+
+	return format.Source(b.Bytes())
+}
+
+// translateStringLiteral returns the fields of a composite literal
+// of a translation, only the fields that are set are written
+func translateStringLiteral(ts goeasyi18n.TranslateString) string {
+	fields := []string{}
+	value := reflect.ValueOf(ts)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.IsZero() {
+			continue
+		}
+
+		var literal string
+		switch field.Kind() {
+		case reflect.String:
+			literal = strconv.Quote(field.String())
+		case reflect.Int:
+			literal = strconv.FormatInt(field.Int(), 10)
+		case reflect.Bool:
+			literal = strconv.FormatBool(field.Bool())
+		case reflect.Slice:
+			items := make([]string, field.Len())
+			for j := range items {
+				items[j] = strconv.Quote(field.Index(j).String())
+			}
+			literal = "[]string{" + strings.Join(items, ", ") + "}"
+		default:
+			continue
+		}
+		fields = append(fields, value.Type().Field(i).Name+": "+literal)
+	}
+	return strings.Join(fields, ", ")
+}
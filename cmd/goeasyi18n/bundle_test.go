@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestBundle(t *testing.T) {
+	t.Run("should generate the translations and the register function", func(t *testing.T) {
+		code, stdout, stderr := runCommand("bundle", "-dir", "testdata/translations")
+		if code != 0 {
+			t.Fatalf("Unexpected error: %s", stderr)
+		}
+
+		expected, err := os.ReadFile("testdata/bundle.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stdout != string(expected) {
+			t.Errorf("expected %s; got %s", expected, stdout)
+		}
+	})
+
+	t.Run("should write only the languages passed", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "catalog.go")
+		code, _, stderr := runCommand("bundle", "-dir", "testdata/translations", "-pkg", "catalog", "-out", output, "-lang", "es")
+		if code != 0 {
+			t.Fatalf("Unexpected error: %s", stderr)
+		}
+
+		written, err := os.ReadFile(output)
+		if err != nil || !strings.Contains(string(written), "package catalog") || strings.Contains(string(written), `"en": {`) {
+			t.Errorf("Unexpected result: %s %v", written, err)
+		}
+	})
+
+	t.Run("should fail with an unknown language", func(t *testing.T) {
+		code, _, stderr := runCommand("bundle", "-dir", "testdata/translations", "-lang", "fr")
+		if code != 1 || stderr == "" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+
+	t.Run("should fail with the Fluent languages", func(t *testing.T) {
+		code, stdout, stderr := runCommand("bundle", "-dir", "../../testfiles/dir")
+		if code != 1 || stdout != "" || !strings.Contains(stderr, "the language 'it' has Fluent messages") {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+
+		code, _, stderr = runCommand("bundle", "-dir", "../../testfiles/dir", "-lang", "en,es")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+}
+
+func TestTranslateStringLiteral(t *testing.T) {
+	ts := goeasyi18n.TranslateString{
+		Key:        "emails",
+		One:        "One \"email\"",
+		Many:       "{{.Count}} emails\n",
+		MaxLength:  20,
+		Tags:       []string{"inbox", "home"},
+		Status:     goeasyi18n.StatusFuzzy,
+		Deprecated: true,
+	}
+
+	expected := `Key: "emails", One: "One \"email\"", Many: "{{.Count}} emails\n", MaxLength: 20, Tags: []string{"inbox", "home"}, Status: "fuzzy", Deprecated: true`
+	if got := translateStringLiteral(ts); got != expected {
+		t.Errorf("expected %s; got %s", expected, got)
+	}
+}
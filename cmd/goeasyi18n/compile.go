@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["compile"] = command{
+		description: "compile the catalogs to a binary catalog that is loaded fast (for go:embed)",
+		run:         runCompile,
+	}
+}
+
+// pluralRulesFlag is a repeatable flag like "ru=One,Few,Many:<expression>"
+// with the plural rule of a language, the expression is a gettext
+// plural expression that returns the index of the plural form
+type pluralRulesFlag map[string]*goeasyi18n.PluralRule
+
+func (f pluralRulesFlag) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name+"="+f[name].String())
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func (f pluralRulesFlag) Set(value string) error {
+	languageName, rule, found := strings.Cut(value, "=")
+	forms, expression, hasExpression := strings.Cut(rule, ":")
+	if !found || languageName == "" || !hasExpression {
+		return fmt.Errorf("the plural rule must be like 'ru=One,Few,Many:<expression>'")
+	}
+
+	pluralRule, err := goeasyi18n.ParsePluralRule(expression, splitList(forms)...)
+	if err != nil {
+		return err
+	}
+	f[languageName] = pluralRule
+	return nil
+}
+
+func runCompile(args []string, stdout io.Writer, stderr io.Writer) int {
+	pluralRules := pluralRulesFlag{}
+	flags := flag.NewFlagSet("compile", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	out := flags.String("out", "", "compiled catalog to write")
+	languages := flags.String("lang", "", "comma separated languages to compile (default: all)")
+	flags.Var(pluralRules, "plural-rule", "plural rule of a language, like 'ru=One,Few,Many:<expression>' (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *out == "" {
+		fmt.Fprintln(stderr, "goeasyi18n: the -out flag is required")
+		return 2
+	}
+
+	i18n, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	languageNames := i18n.LanguageNames()
+	if *languages != "" {
+		languageNames = splitList(*languages)
+	}
+
+	translations := map[string]goeasyi18n.TranslateStrings{}
+	keys := 0
+	for _, languageName := range languageNames {
+		if !i18n.HasLanguage(languageName) {
+			fmt.Fprintf(stderr, "goeasyi18n: the language '%s' doesn't exist in %s\n", languageName, *dir)
+			return 1
+		}
+		if i18n.HasFluentLanguage(languageName) {
+			fmt.Fprintf(stderr, "goeasyi18n: the language '%s' has Fluent messages that can't be compiled, use -lang to compile the other languages\n", languageName)
+			return 1
+		}
+		translations[languageName] = i18n.Translations(languageName)
+		keys += len(translations[languageName])
+	}
+
+	for languageName := range pluralRules {
+		if _, exists := translations[languageName]; !exists {
+			fmt.Fprintf(stderr, "goeasyi18n: the plural rule of the language '%s' is not compiled\n", languageName)
+			return 1
+		}
+	}
+
+	data, err := goeasyi18n.MarshalCompiledCatalogWithPluralRules(translations, pluralRules)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "compiled %d languages and %d translations to %s (%d bytes)\n", len(translations), keys, *out, len(data))
+	return 0
+}
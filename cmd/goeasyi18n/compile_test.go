@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestCompile(t *testing.T) {
+	newCatalogs := func(t *testing.T) string {
+		return writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello {{.Name}}\n- Key: bye\n  Default: Bye\n",
+			"es.json": `[{"Key": "hello", "Default": "Hola {{.Name}}"}, {"Key": "bye", "Default": "Adiós"}]`,
+		})
+	}
+
+	t.Run("should compile all the languages", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		code, stdout, stderr := runCommand("compile", "-dir", newCatalogs(t), "-out", out)
+		if code != 0 || !strings.HasPrefix(stdout, "compiled 2 languages and 4 translations to ") {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		i18n := goeasyi18n.NewI18n()
+		if err := i18n.LoadCompiledCatalog(data); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if got := i18n.T("es", "bye"); got != "Adiós" {
+			t.Errorf("expected %s; got %s", "Adiós", got)
+		}
+	})
+
+	t.Run("should compile only the languages passed", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		code, _, _ := runCommand("compile", "-dir", newCatalogs(t), "-out", out, "-lang", "es")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+
+		translations, err := goeasyi18n.LoadFromCompiledCatalogFile(out)
+		if err != nil || len(translations) != 1 || len(translations["es"]) != 2 {
+			t.Errorf("Unexpected result: %v %v", translations, err)
+		}
+	})
+
+	t.Run("should compile the plural rules", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"ru.json": `[{"Key": "files", "One": "файл", "Few": "файла", "Many": "файлов"}]`,
+		})
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		rule := "ru=One,Few,Many:n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2"
+		code, _, stderr := runCommand("compile", "-dir", dir, "-out", out, "-plural-rule", rule)
+		if code != 0 {
+			t.Fatalf("Unexpected result: %d %s", code, stderr)
+		}
+
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		i18n := goeasyi18n.NewI18n()
+		if err := i18n.LoadCompiledCatalog(data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for count, expected := range map[int]string{1: "файл", 3: "файла", 5: "файлов", 21: "файл"} {
+			if got := i18n.T("ru", "files", goeasyi18n.Options{Count: &count}); got != expected {
+				t.Errorf("expected %s; got %s", expected, got)
+			}
+		}
+	})
+
+	t.Run("should fail with an invalid plural rule", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		rules := []string{"en", "en=One,Many", "en=One,Many:n ==", "en=Other:n != 1"}
+		for _, rule := range rules {
+			code, _, _ := runCommand("compile", "-dir", newCatalogs(t), "-out", out, "-plural-rule", rule)
+			if code != 2 {
+				t.Errorf("%s: expected %d; got %d", rule, 2, code)
+			}
+		}
+
+		code, _, stderr := runCommand("compile", "-dir", newCatalogs(t), "-out", out, "-lang", "es", "-plural-rule", "en=One,Many:n != 1")
+		if code != 1 || stderr == "" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+
+	t.Run("should fail with an unknown language", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		code, _, stderr := runCommand("compile", "-dir", newCatalogs(t), "-out", out, "-lang", "fr")
+		if code != 1 || stderr == "" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+
+	t.Run("should fail with the Fluent languages", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "catalog.bin")
+		code, _, stderr := runCommand("compile", "-dir", "../../testfiles/dir", "-out", out)
+		if code != 1 || !strings.Contains(stderr, "the language 'it' has Fluent messages") {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+		if _, err := os.Stat(out); err == nil {
+			t.Errorf("Unexpected compiled catalog")
+		}
+
+		code, _, stderr = runCommand("compile", "-dir", "../../testfiles/dir", "-out", out, "-lang", "en,es")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+
+	t.Run("should require the output", func(t *testing.T) {
+		code, _, _ := runCommand("compile", "-dir", newCatalogs(t))
+		if code != 2 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+	})
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["convert"] = command{
+		description: "convert a catalog to another format (JSON, YAML, TOML, PO, XLIFF, ARB, CSV, ...)",
+		run:         runConvert,
+	}
+}
+
+// convertFormats are the formats of the convert command
+var convertFormats = []string{"json", "nested-json", "yaml", "toml", "properties", "po", "arb", "xliff", "csv"}
+
+func runConvert(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in := flags.String("in", "", "catalog to convert")
+	out := flags.String("out", "", "converted catalog, - writes it to the standard output")
+	from := flags.String("from", "", "format of the input ("+strings.Join(convertFormats, ", ")+"), by default it is inferred from the extension")
+	to := flags.String("to", "", "format of the output, by default it is inferred from the extension")
+	languageName := flags.String("lang", "", "language of a single language input, or language to convert from a CSV or XLIFF input (the target language of an XLIFF output)")
+	sourceLanguage := flags.String("source", "en", "source language of an XLIFF output")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *in == "" || *out == "" {
+		fmt.Fprintln(stderr, "goeasyi18n: the -in and -out flags are required")
+		return 2
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	inputFormat := *from
+	if inputFormat == "" {
+		inputFormat = formatFromExtension(*in)
+		// The nested JSON files are objects instead of lists
+		if inputFormat == "json" && bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			inputFormat = "nested-json"
+		}
+	}
+	outputFormat := *to
+	if outputFormat == "" && *out != "-" {
+		outputFormat = formatFromExtension(*out)
+	}
+	for _, format := range []string{inputFormat, outputFormat} {
+		if !isConvertFormat(format) {
+			fmt.Fprintf(stderr, "goeasyi18n: unknown format '%s', use -from and -to with one of: %s\n", format, strings.Join(convertFormats, ", "))
+			return 2
+		}
+	}
+
+	translations, err := loadCatalog(inputFormat, data, *in, *languageName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	converted, err := marshalCatalog(outputFormat, translations, *languageName, *sourceLanguage)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *out == "-" {
+		_, _ = stdout.Write(converted)
+		return 0
+	}
+	if err := os.WriteFile(*out, converted, 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	languageNames := sortedLanguageNames(translations)
+	fmt.Fprintf(stdout, "converted %s (%s) from %s to %s\n", *in, strings.Join(languageNames, ", "), inputFormat, outputFormat)
+	return 0
+}
+
+// formatFromExtension infers the format of a file,
+// the .json files are TranslateStrings lists
+func formatFromExtension(path string) string {
+	switch extension := strings.ToLower(filepath.Ext(path)); extension {
+	case ".yml":
+		return "yaml"
+	case ".pot":
+		return "po"
+	case ".xlf":
+		return "xliff"
+	default:
+		return strings.TrimPrefix(extension, ".")
+	}
+}
+
+func isConvertFormat(format string) bool {
+	for _, f := range convertFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCatalog loads the translations of a catalog by language, the
+// language of the single language formats is the -lang flag, the
+// language of the file (the ARB locale or the PO header) or the
+// language inferred from the file name like LoadDir does it
+func loadCatalog(
+	format string,
+	data []byte,
+	path string,
+	languageName string,
+) (map[string]goeasyi18n.TranslateStrings, error) {
+	switch format {
+	case "csv":
+		return goeasyi18n.LoadFromCsvBytes(data)
+	case "xliff":
+		return goeasyi18n.LoadFromXliffBytes(data)
+	}
+
+	loaders := map[string]func([]byte) (goeasyi18n.TranslateStrings, error){
+		"json":        goeasyi18n.LoadFromJsonBytes,
+		"nested-json": goeasyi18n.LoadFromNestedJsonBytes,
+		"yaml":        goeasyi18n.LoadFromYamlBytes,
+		"toml":        goeasyi18n.LoadFromTomlBytes,
+		"properties":  goeasyi18n.LoadFromPropertiesBytes,
+		"po":          goeasyi18n.LoadFromPoBytes,
+		"arb":         goeasyi18n.LoadFromArbBytes,
+	}
+	translateStrings, err := loaders[format](data)
+	if err != nil {
+		return nil, err
+	}
+
+	if languageName == "" {
+		languageName = fileLanguageName(format, data)
+	}
+	if languageName == "" {
+		name := filepath.Base(path)
+		languageName = strings.TrimSuffix(name, filepath.Ext(name))
+		if dot := strings.LastIndex(languageName, "."); dot >= 0 {
+			languageName = languageName[dot+1:]
+		}
+	}
+
+	return map[string]goeasyi18n.TranslateStrings{languageName: translateStrings}, nil
+}
+
+// poLanguageHeader finds the language of the header of a PO file
+var poLanguageHeader = regexp.MustCompile(`(?m)^"Language:\s*([^\\"]+)`)
+
+// fileLanguageName returns the language written in
+// the ARB and PO files, if they have one
+func fileLanguageName(format string, data []byte) string {
+	switch format {
+	case "arb":
+		var header struct {
+			Locale string `json:"@@locale"`
+		}
+		if err := json.Unmarshal(data, &header); err == nil {
+			return header.Locale
+		}
+	case "po":
+		if match := poLanguageHeader.FindSubmatch(data); match != nil {
+			return strings.TrimSpace(string(match[1]))
+		}
+	}
+	return ""
+}
+
+// marshalCatalog serializes the translations, the single language formats
+// need a single language or the one that is picked with -lang
+func marshalCatalog(
+	format string,
+	translations map[string]goeasyi18n.TranslateStrings,
+	languageName string,
+	sourceLanguage string,
+) ([]byte, error) {
+	languageNames := sortedLanguageNames(translations)
+
+	switch format {
+	case "csv":
+		return goeasyi18n.MarshalCsv(translations)
+	case "xliff":
+		// A single language is written as the source texts
+		if len(languageNames) == 1 {
+			return goeasyi18n.MarshalXliff(translations, languageNames[0], "")
+		}
+		targetLanguage := languageName
+		if targetLanguage == "" && len(languageNames) == 2 {
+			for _, name := range languageNames {
+				if name != sourceLanguage {
+					targetLanguage = name
+				}
+			}
+		}
+		if targetLanguage == "" {
+			return nil, fmt.Errorf("goeasyi18n: the input has the languages %s, pick the target language with -lang", strings.Join(languageNames, ", "))
+		}
+		return goeasyi18n.MarshalXliff(translations, sourceLanguage, targetLanguage)
+	}
+
+	if languageName == "" {
+		if len(languageNames) != 1 {
+			return nil, fmt.Errorf("goeasyi18n: the input has the languages %s, pick one with -lang", strings.Join(languageNames, ", "))
+		}
+		languageName = languageNames[0]
+	}
+	translateStrings, exists := translations[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist in the input", languageName)
+	}
+
+	switch format {
+	case "json":
+		return goeasyi18n.MarshalJson(translateStrings)
+	case "nested-json":
+		return goeasyi18n.MarshalNestedJson(translateStrings)
+	case "yaml":
+		return goeasyi18n.MarshalYaml(translateStrings)
+	case "toml":
+		return goeasyi18n.MarshalToml(translateStrings)
+	case "properties":
+		return goeasyi18n.MarshalProperties(translateStrings)
+	case "po":
+		return goeasyi18n.MarshalPo(translateStrings, languageName)
+	default:
+		return goeasyi18n.MarshalArb(translateStrings, languageName)
+	}
+}
+
+func sortedLanguageNames(translations map[string]goeasyi18n.TranslateStrings) []string {
+	languageNames := make([]string, 0, len(translations))
+	for languageName := range translations {
+		languageNames = append(languageNames, languageName)
+	}
+	sort.Strings(languageNames)
+	return languageNames
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestConvert(t *testing.T) {
+	dir := writeCatalogs(t, map[string]string{
+		"messages.po": `msgid ""
+msgstr ""
+"Language: es\n"
+
+msgid "home.title"
+msgstr "Inicio"
+
+msgid "home.emails"
+msgid_plural "home.emails"
+msgstr[0] "Un correo"
+msgstr[1] "{{.Qty}} correos"
+`,
+		"en.json":       `[{"Key": "hello", "Default": "Hello {{.Name}}"}, {"Key": "bye", "Default": "Bye"}]`,
+		"nested.json":   `{"home": {"title": "Home"}}`,
+		"languages.csv": "Key,en,es\nhello,Hello,Hola\n",
+	})
+
+	t.Run("should convert po to nested json", func(t *testing.T) {
+		code, stdout, stderr := runCommand("convert", "-in", filepath.Join(dir, "messages.po"), "-out", "-", "-to", "nested-json")
+
+		expected := `{
+  "home": {
+    "emails": {
+      "One": "Un correo",
+      "Many": "{{.Qty}} correos"
+    },
+    "title": "Inicio"
+  }
+}
+`
+		if code != 0 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+	})
+
+	t.Run("should infer the formats and the language from the files", func(t *testing.T) {
+		out := filepath.Join(dir, "app.arb")
+		code, stdout, stderr := runCommand("convert", "-in", filepath.Join(dir, "en.json"), "-out", out)
+		if code != 0 || !strings.HasSuffix(stdout, "(en) from json to arb\n") {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+
+		code, stdout, _ = runCommand("convert", "-in", out, "-out", "-", "-to", "yaml")
+		expected := `- Key: bye
+  Default: Bye
+- Key: hello
+  Default: Hello {{.Name}}
+`
+		if code != 0 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should detect nested json", func(t *testing.T) {
+		code, stdout, _ := runCommand("convert", "-in", filepath.Join(dir, "nested.json"), "-out", "-", "-to", "properties")
+		if code != 0 || stdout != "home.title = Home\n" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should pick a language of a multi language input", func(t *testing.T) {
+		code, _, stderr := runCommand("convert", "-in", filepath.Join(dir, "languages.csv"), "-out", "-", "-to", "toml")
+		if code != 1 || stderr != "goeasyi18n: the input has the languages en, es, pick one with -lang\n" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+
+		code, stdout, _ := runCommand("convert", "-in", filepath.Join(dir, "languages.csv"), "-out", "-", "-to", "toml", "-lang", "es")
+		if code != 0 || stdout != "[hello]\nDefault = \"Hola\"\n" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should convert csv to xliff", func(t *testing.T) {
+		out := filepath.Join(dir, "es.xlf")
+		code, _, stderr := runCommand("convert", "-in", filepath.Join(dir, "languages.csv"), "-out", out)
+		if code != 0 {
+			t.Fatalf("Unexpected result: %d %s", code, stderr)
+		}
+
+		translations, err := goeasyi18n.LoadFromXliffFiles(out)
+		if err != nil || len(translations["es"]) != 1 || translations["es"][0].Default != "Hola" {
+			t.Errorf("Unexpected result: %v %v", translations, err)
+		}
+	})
+
+	t.Run("should fail with unknown formats", func(t *testing.T) {
+		code, _, stderr := runCommand("convert", "-in", filepath.Join(dir, "en.json"), "-out", "en.txt")
+		if code != 2 || !strings.Contains(stderr, "unknown format 'txt'") {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+
+		code, _, _ = runCommand("convert", "-in", filepath.Join(dir, "en.json"))
+		if code != 2 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["coverage"] = command{
+		description: "report the translated keys and the words to translate of every language",
+		run:         runCoverage,
+	}
+}
+
+func runCoverage(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	sourceLanguage := flags.String("lang", "en", "source language whose keys are translated")
+	format := flags.String("format", "text", "output format: text, json or markdown")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "text" && *format != "json" && *format != "markdown" {
+		fmt.Fprintf(stderr, "goeasyi18n: unknown format '%s', use text, json or markdown\n", *format)
+		return 2
+	}
+
+	i18n, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if !i18n.HasLanguage(*sourceLanguage) {
+		fmt.Fprintf(stderr, "goeasyi18n: the source language '%s' doesn't exist in %s\n", *sourceLanguage, *dir)
+		return 1
+	}
+
+	coverages := i18n.Coverage(*sourceLanguage)
+	switch *format {
+	case "json":
+		data, err := json.MarshalIndent(coverages, "", "  ")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data))
+	case "markdown":
+		writeCoverageMarkdown(stdout, coverages)
+	default:
+		writeCoverageText(stdout, coverages)
+	}
+	return 0
+}
+
+// writeCoverageText writes a summary line per language
+// followed by the keys that need to be translated
+func writeCoverageText(w io.Writer, coverages []goeasyi18n.LanguageCoverage) {
+	for _, c := range coverages {
+		fmt.Fprintf(
+			w,
+			"%s: %.1f%% translated (%d of %d keys), %d missing, %d with missing variants, %d identical to the source, %d of %d words to translate\n",
+			c.LanguageName,
+			c.Percent,
+			c.TranslatedKeys,
+			c.TotalKeys,
+			len(c.MissingKeys),
+			len(c.MissingVariants),
+			len(c.IdenticalKeys),
+			c.MissingWords,
+			c.SourceWords,
+		)
+		for _, key := range c.MissingKeys {
+			fmt.Fprintf(w, "  missing: %s\n", describeKey(key.Key, key.Context))
+		}
+		for _, key := range c.MissingVariants {
+			fmt.Fprintf(w, "  missing variants: %s (%s)\n", describeKey(key.Key, key.Context), strings.Join(key.Variants, ", "))
+		}
+		for _, key := range c.IdenticalKeys {
+			fmt.Fprintf(w, "  identical: %s\n", describeKey(key.Key, key.Context))
+		}
+	}
+}
+
+// writeCoverageMarkdown writes a table with a row per language
+func writeCoverageMarkdown(w io.Writer, coverages []goeasyi18n.LanguageCoverage) {
+	fmt.Fprintln(w, "| Language | Coverage | Translated | Missing | Missing variants | Identical | Words to translate |")
+	fmt.Fprintln(w, "| --- | ---: | ---: | ---: | ---: | ---: | ---: |")
+	for _, c := range coverages {
+		fmt.Fprintf(
+			w,
+			"| %s | %.1f%% | %d/%d | %d | %d | %d | %d/%d |\n",
+			c.LanguageName,
+			c.Percent,
+			c.TranslatedKeys,
+			c.TotalKeys,
+			len(c.MissingKeys),
+			len(c.MissingVariants),
+			len(c.IdenticalKeys),
+			c.MissingWords,
+			c.SourceWords,
+		)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestCoverage(t *testing.T) {
+	newCatalogs := func(t *testing.T) string {
+		return writeCatalogs(t, map[string]string{
+			"en.yaml": `- Key: hello
+  Default: Hello {{.Name}}
+- Key: emails
+  One: You have one email
+  Many: You have {{.Count}} emails
+- Key: ok
+  Default: OK
+- Key: open
+  Context: verb
+  Default: Open
+`,
+			"es.yaml": `- Key: hello
+  Default: Hola {{.Name}}
+- Key: emails
+  Many: Tienes {{.Count}} correos
+- Key: ok
+  Default: OK
+`,
+		})
+	}
+
+	t.Run("should report the coverage as text", func(t *testing.T) {
+		code, stdout, stderr := runCommand("coverage", "-dir", newCatalogs(t))
+
+		expected := `es: 75.0% translated (3 of 4 keys), 1 missing, 1 with missing variants, 1 identical to the source, 5 of 10 words to translate
+  missing: key 'open' with the context 'verb'
+  missing variants: key 'emails' (One)
+  identical: key 'ok'
+`
+		if code != 0 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+	})
+
+	t.Run("should report the coverage as a markdown table", func(t *testing.T) {
+		code, stdout, _ := runCommand("coverage", "-dir", newCatalogs(t), "-format", "markdown")
+
+		expected := `| Language | Coverage | Translated | Missing | Missing variants | Identical | Words to translate |
+| --- | ---: | ---: | ---: | ---: | ---: | ---: |
+| es | 75.0% | 3/4 | 1 | 1 | 1 | 5/10 |
+`
+		if code != 0 || stdout != expected {
+			t.Errorf("expected %s; got %s", expected, stdout)
+		}
+	})
+
+	t.Run("should report the coverage as json", func(t *testing.T) {
+		code, stdout, _ := runCommand("coverage", "-dir", newCatalogs(t), "-format", "json")
+
+		coverages := []goeasyi18n.LanguageCoverage{}
+		if err := json.Unmarshal([]byte(stdout), &coverages); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if code != 0 || len(coverages) != 1 || coverages[0].LanguageName != "es" || coverages[0].MissingWords != 5 {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should fail with an unknown source language", func(t *testing.T) {
+		code, _, stderr := runCommand("coverage", "-dir", newCatalogs(t), "-lang", "fr")
+		if code != 1 || stderr == "" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+
+	t.Run("should fail with an unknown format", func(t *testing.T) {
+		code, _, _ := runCommand("coverage", "-dir", newCatalogs(t), "-format", "html")
+		if code != 2 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["diff"] = command{
+		description: "list the keys and variants added, removed or modified between two catalog directories",
+		run:         runDiff,
+	}
+}
+
+func runDiff(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	oldDir := flags.String("old", "", "directory with the old version of the catalogs")
+	newDir := flags.String("new", "", "directory with the new version of the catalogs")
+	languageName := flags.String("lang", "", "compare only this language")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *oldDir == "" || *newDir == "" {
+		fmt.Fprintln(stderr, "goeasyi18n: the -old and -new flags are required")
+		return 2
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "goeasyi18n: unknown format '%s', use text or json\n", *format)
+		return 2
+	}
+
+	older, err := loadCatalogs(*oldDir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	newer, err := loadCatalogs(*newDir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	changes := []goeasyi18n.CatalogChange{}
+	for _, change := range older.Diff(newer) {
+		if *languageName == "" || change.LanguageName == *languageName {
+			changes = append(changes, change)
+		}
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		for _, change := range changes {
+			fmt.Fprintln(stdout, describeChange(change))
+		}
+		switch len(changes) {
+		case 0:
+			fmt.Fprintln(stdout, "no changes")
+		case 1:
+			fmt.Fprintln(stdout, "1 change")
+		default:
+			fmt.Fprintf(stdout, "%d changes\n", len(changes))
+		}
+	}
+
+	// Like diff, the exit status tells if there are changes
+	if len(changes) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// describeChange formats a change as a line of the text output
+func describeChange(change goeasyi18n.CatalogChange) string {
+	prefix := change.LanguageName + ": " + describeKey(change.Key, change.Context)
+	if change.Field == "" {
+		return fmt.Sprintf("%s: %s", prefix, change.Type)
+	}
+
+	switch change.Type {
+	case goeasyi18n.ChangeAdded:
+		return fmt.Sprintf("%s: added %s %q", prefix, change.Field, change.NewValue)
+	case goeasyi18n.ChangeRemoved:
+		return fmt.Sprintf("%s: removed %s %q", prefix, change.Field, change.OldValue)
+	default:
+		return fmt.Sprintf("%s: modified %s %q -> %q", prefix, change.Field, change.OldValue, change.NewValue)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestDiff(t *testing.T) {
+	newDirs := func(t *testing.T) (string, string) {
+		oldDir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello\n- Key: bye\n  Default: Bye\n",
+			"es.yaml": "- Key: hello\n  Default: Hola\n- Key: bye\n  Default: Adiós\n  Status: fuzzy\n",
+		})
+		newDir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello\n- Key: bye\n  Default: Bye\n",
+			"es.json": `[
+  {"Key": "hello", "Default": "¡Hola!"},
+  {"Key": "bye", "Default": "Adiós"},
+  {"Key": "open", "Context": "verb", "Default": "Abrir"}
+]`,
+		})
+		return oldDir, newDir
+	}
+
+	t.Run("should list the changes", func(t *testing.T) {
+		oldDir, newDir := newDirs(t)
+		code, stdout, stderr := runCommand("diff", "-old", oldDir, "-new", newDir)
+
+		expected := `es: key 'bye': removed Status "fuzzy"
+es: key 'hello': modified Default "Hola" -> "¡Hola!"
+es: key 'open' with the context 'verb': added
+3 changes
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+	})
+
+	t.Run("should write the changes as json", func(t *testing.T) {
+		oldDir, newDir := newDirs(t)
+		code, stdout, _ := runCommand("diff", "-old", oldDir, "-new", newDir, "-format", "json")
+
+		changes := []goeasyi18n.CatalogChange{}
+		if err := json.Unmarshal([]byte(stdout), &changes); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if code != 1 || len(changes) != 3 || changes[1].Type != goeasyi18n.ChangeModified || changes[1].NewValue != "¡Hola!" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should compare only a language", func(t *testing.T) {
+		oldDir, newDir := newDirs(t)
+		code, stdout, _ := runCommand("diff", "-old", oldDir, "-new", newDir, "-lang", "en")
+		if code != 0 || stdout != "no changes\n" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should require both directories", func(t *testing.T) {
+		code, _, _ := runCommand("diff", "-old", "testdata")
+		if code != 2 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+	})
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["extract"] = command{
+		description: "find the keys used in Go code and templates that are missing or unused",
+		run:         runExtract,
+	}
+}
+
+func runExtract(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("extract", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	src := flags.String("src", ".", "directory with the Go code and the templates")
+	sourceLanguage := flags.String("lang", "en", "language whose keys are compared with the used keys")
+	templateExtensions := flags.String("templates", ".html,.gohtml,.tmpl,.tpl", "comma separated extensions of the template files")
+	templateFuncs := flags.String("funcs", "Translate,T", "comma separated names of the translate functions of the templates")
+	catalog := flags.String("catalog", "", "write the used keys as a source catalog (.json, .yaml or .yml), the key is also the text")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	usages, err := extractKeys(*src, splitList(*templateExtensions), splitList(*templateFuncs))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *catalog != "" {
+		if err := writeSourceCatalog(*catalog, usages); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	i18n, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	missing, unused := compareKeys(usages, i18n.Translations(*sourceLanguage))
+	for _, usage := range missing {
+		fmt.Fprintf(stdout, "missing: %s (%s)\n", describeKey(usage.key, usage.context), usage.position)
+	}
+	for _, ts := range unused {
+		fmt.Fprintf(stdout, "unused: %s\n", describeKey(ts.Key, ts.Context))
+	}
+
+	if len(missing) > 0 || len(unused) > 0 {
+		fmt.Fprintf(stdout, "%d missing and %d unused keys\n", len(missing), len(unused))
+		return 1
+	}
+	fmt.Fprintln(stdout, "all the keys are in sync")
+	return 0
+}
+
+// keyUsage is a key (and its context) used at a position
+// of the code or the templates
+type keyUsage struct {
+	key      string
+	context  string
+	position string
+}
+
+// generatedGoComment is the comment of the generated Go files
+// (see https://go.dev/s/generatedcode), it must be before the
+// package clause
+var (
+	generatedGoComment = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+	goPackageClause    = regexp.MustCompile(`(?m)^package `)
+)
+
+// isGeneratedGo checks if a Go file is generated code
+func isGeneratedGo(src []byte) bool {
+	header := src
+	if packageClause := goPackageClause.FindIndex(src); packageClause != nil {
+		header = src[:packageClause[0]]
+	}
+	return generatedGoComment.Match(header)
+}
+
+// extractKeys finds the keys used in the Go files (except the tests
+// and the generated code) and in the template files of a directory
+// (except the testdata), the keys that are not string literals can't
+// be known so they are ignored
+func extractKeys(root string, templateExtensions []string, templateFuncs []string) ([]keyUsage, error) {
+	var usages []keyUsage
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		isGo := strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+		isTemplate := false
+		for _, extension := range templateExtensions {
+			if strings.EqualFold(filepath.Ext(name), extension) {
+				isTemplate = true
+			}
+		}
+		if !isGo && !isTemplate {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// The generated code only uses the keys of the catalogs
+		if isGo && isGeneratedGo(src) {
+			return nil
+		}
+
+		var fileUsages []keyUsage
+		if isGo {
+			fileUsages, err = extractGoKeys(path, src)
+		} else {
+			fileUsages, err = extractTemplateKeys(path, string(src), templateFuncs)
+		}
+		if err != nil {
+			return err
+		}
+		usages = append(usages, fileUsages...)
+		return nil
+	})
+
+	return usages, err
+}
+
+// extractGoKeys finds the keys of the calls to Translate, T and
+// TranslateContext and of the calls to the functions created with
+// NewLangTranslateFunc (assigned to a variable in the same file)
+func extractGoKeys(fileName string, src []byte) ([]keyUsage, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, fileName, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// The names of the variables with a NewLangTranslateFunc function
+	langFuncs := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		var names []*ast.Ident
+		var values []ast.Expr
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				ident, _ := lhs.(*ast.Ident)
+				names = append(names, ident)
+			}
+			values = n.Rhs
+		case *ast.ValueSpec:
+			names = n.Names
+			values = n.Values
+		}
+		for i, value := range values {
+			if i < len(names) && names[i] != nil && isMethodCall(value, "NewLangTranslateFunc") {
+				langFuncs[names[i].Name] = true
+			}
+		}
+		return true
+	})
+
+	var usages []keyUsage
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		keyIndex := -1
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			switch fun.Sel.Name {
+			case "Translate", "T":
+				keyIndex = 1
+			case "TranslateContext":
+				keyIndex = 2
+			}
+		case *ast.Ident:
+			if langFuncs[fun.Name] {
+				keyIndex = 0
+			}
+		}
+		if keyIndex < 0 || keyIndex >= len(call.Args) {
+			return true
+		}
+
+		key, isLiteral := stringLiteral(call.Args[keyIndex])
+		if !isLiteral {
+			return true
+		}
+		usage := keyUsage{key: key, position: fileSet.Position(call.Args[keyIndex].Pos()).String()}
+		if keyIndex+1 < len(call.Args) {
+			usage.context = optionsContext(call.Args[keyIndex+1])
+		}
+		usages = append(usages, usage)
+		return true
+	})
+
+	return usages, nil
+}
+
+// isMethodCall checks if the expression is a call to a method
+func isMethodCall(expr ast.Expr, name string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == name
+}
+
+// stringLiteral returns the value of a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	return value, err == nil
+}
+
+// optionsContext returns the Context of an Options
+// literal, like Options{Context: "status"}
+func optionsContext(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	composite, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	for _, element := range composite.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := keyValue.Key.(*ast.Ident); ok && ident.Name == "Context" {
+			context, _ := stringLiteral(keyValue.Value)
+			return context
+		}
+	}
+	return ""
+}
+
+// extractTemplateKeys finds the keys of the translate functions of a
+// template, like {{ Translate "lang" "en" "key" "hello" }}, the other
+// functions of the template don't need to be known
+func extractTemplateKeys(fileName string, src string, templateFuncs []string) ([]keyUsage, error) {
+	tree := parse.New(fileName)
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := tree.Parse(src, "{{", "}}", trees); err != nil {
+		return nil, err
+	}
+
+	isTranslateFunc := map[string]bool{}
+	for _, name := range templateFuncs {
+		isTranslateFunc[name] = true
+	}
+
+	var usages []keyUsage
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			if usage, found := templateKeyUsage(fileName, src, n, isTranslateFunc); found {
+				usages = append(usages, usage)
+			}
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		}
+	}
+
+	names := make([]string, 0, len(trees))
+	for name := range trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		walk(trees[name].Root)
+	}
+
+	return usages, nil
+}
+
+// templateKeyUsage reads the "key" and "context" arguments
+// of a call to a translate function of a template
+func templateKeyUsage(
+	fileName string,
+	src string,
+	cmd *parse.CommandNode,
+	isTranslateFunc map[string]bool,
+) (keyUsage, bool) {
+	if len(cmd.Args) == 0 {
+		return keyUsage{}, false
+	}
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok || !isTranslateFunc[ident.Ident] {
+		return keyUsage{}, false
+	}
+
+	usage := keyUsage{}
+	for i := 1; i+1 < len(cmd.Args); i += 2 {
+		name, ok := cmd.Args[i].(*parse.StringNode)
+		if !ok {
+			continue
+		}
+		value, ok := cmd.Args[i+1].(*parse.StringNode)
+		if !ok {
+			continue
+		}
+		switch name.Text {
+		case "key":
+			usage.key = value.Text
+			line := strings.Count(src[:value.Position()], "\n") + 1
+			usage.position = fmt.Sprintf("%s:%d", fileName, line)
+		case "context":
+			usage.context = value.Text
+		}
+	}
+
+	return usage, usage.key != ""
+}
+
+// compareKeys returns the used keys that are not in the catalog (once
+// per key) and the keys of the catalog that are not used, the aliases
+// and the deprecated keys are not reported as unused
+func compareKeys(
+	usages []keyUsage,
+	catalog goeasyi18n.TranslateStrings,
+) ([]keyUsage, goeasyi18n.TranslateStrings) {
+	inCatalog := map[string]bool{}
+	for _, ts := range catalog {
+		inCatalog[ts.Context+"\x04"+ts.Key] = true
+	}
+
+	used := map[string]bool{}
+	var missing []keyUsage
+	for _, usage := range usages {
+		id := usage.context + "\x04" + usage.key
+		if !inCatalog[id] && !used[id] {
+			missing = append(missing, usage)
+		}
+		used[id] = true
+	}
+
+	var unused goeasyi18n.TranslateStrings
+	for _, ts := range catalog {
+		if !used[ts.Context+"\x04"+ts.Key] && ts.AliasOf == "" && !ts.Deprecated {
+			unused = append(unused, ts)
+		}
+	}
+
+	return missing, unused
+}
+
+// writeSourceCatalog writes the used keys as a source catalog
+// where every key is also its text (see CatalogFromKeys)
+func writeSourceCatalog(path string, usages []keyUsage) error {
+	keys := []string{}
+	withContext := goeasyi18n.TranslateStrings{}
+	seen := map[string]bool{}
+	for _, usage := range usages {
+		if usage.context == "" {
+			keys = append(keys, usage.key)
+			continue
+		}
+		if id := usage.context + "\x04" + usage.key; !seen[id] {
+			seen[id] = true
+			withContext = append(withContext, goeasyi18n.TranslateString{
+				Key:     usage.key,
+				Context: usage.context,
+				Default: usage.key,
+			})
+		}
+	}
+	catalog := append(goeasyi18n.CatalogFromKeys(keys...), withContext...)
+
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err = goeasyi18n.MarshalJson(catalog)
+	case ".yaml", ".yml":
+		data, err = goeasyi18n.MarshalYaml(catalog)
+	default:
+		return fmt.Errorf("goeasyi18n: the catalog must be a .json, .yaml or .yml file")
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// splitList splits a comma separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	t.Run("should report the missing and unused keys", func(t *testing.T) {
+		code, stdout, stderr := runCommand(
+			"extract",
+			"-dir", "testdata/extract/translations",
+			"-src", "testdata/extract/src",
+		)
+
+		expected := `missing: key 'new_feature' (testdata/extract/src/main.go:15:5)
+missing: key 'raw_key' (testdata/extract/src/main.go:18:5)
+missing: key 'open' with the context 'verb' (testdata/extract/src/views/index.html:5)
+unused: key 'unused_key'
+3 missing and 1 unused keys
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s %s", code, stdout, stderr)
+		}
+	})
+
+	t.Run("should write the source catalog", func(t *testing.T) {
+		catalog := filepath.Join(t.TempDir(), "en.yaml")
+		runCommand(
+			"extract",
+			"-dir", "testdata/extract/translations",
+			"-src", "testdata/extract/src/views",
+			"-catalog", catalog,
+		)
+
+		written, err := os.ReadFile(catalog)
+		expected := `- Key: open
+  Context: verb
+  Default: open
+- Key: title
+  Default: title
+- Key: welcome
+  Default: welcome
+`
+		if err != nil || string(written) != expected {
+			t.Errorf("Unexpected result: %s %v", written, err)
+		}
+	})
+}
+
+func TestExtractGoKeys(t *testing.T) {
+	src := []byte(`package main
+
+func main() {
+	i18n.T("en", "a")
+	i18n.Translate(lang, "b", goeasyi18n.Options{Context: "menu"})
+	i18n.TranslateContext(ctx, "en", "c", &goeasyi18n.Options{Context: "x"})
+	i18n.T("en", dynamic)
+	fmt.Println("not a key")
+	tr := i18n.NewLangTranslateFunc("es")
+	tr("d")
+	other("e")
+}
+`)
+
+	usages, err := extractGoKeys("main.go", src)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []keyUsage{
+		{key: "a", position: "main.go:4:15"},
+		{key: "b", context: "menu", position: "main.go:5:23"},
+		{key: "c", context: "x", position: "main.go:6:35"},
+		{key: "d", position: "main.go:10:5"},
+	}
+	if !reflect.DeepEqual(usages, expected) {
+		t.Errorf("Unexpected result: %v", usages)
+	}
+}
+
+func TestExtractTemplateKeys(t *testing.T) {
+	src := `{{define "x"}}{{T "key" "a"}}{{end}}
+{{range .Items}}{{Translate "lang" "en" "key" "b" "count" .Qty}}{{end}}
+{{with .User}}{{Translate "key" "c" "context" "menu"}}{{end}}
+{{Translate "key" .Dynamic}}
+{{other "key" "d"}}`
+
+	usages, err := extractTemplateKeys("index.html", src, []string{"Translate", "T"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []keyUsage{
+		{key: "b", position: "index.html:2"},
+		{key: "c", context: "menu", position: "index.html:3"},
+		{key: "a", position: "index.html:1"},
+	}
+	if !reflect.DeepEqual(usages, expected) {
+		t.Errorf("Unexpected result: %v", usages)
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["gen"] = command{
+		description: "generate a Go package with a typed function per key",
+		run:         runGen,
+	}
+}
+
+func runGen(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	sourceLanguage := flags.String("lang", "en", "language whose texts are used in the doc comments")
+	packageName := flags.String("pkg", "translations", "name of the generated package")
+	output := flags.String("out", "", "file to write the generated code (default: stdout)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	i18n, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	code, err := generateCode(i18n, *sourceLanguage, *packageName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *output == "" {
+		stdout.Write(code)
+		return 0
+	}
+	if err := os.WriteFile(*output, code, 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// generatedKey is a key (with its context) and
+// what its generated function needs
+type generatedKey struct {
+	key          string
+	context      string
+	funcName     string
+	texts        []string
+	placeholders []string
+	hasCount     bool
+	hasGender    bool
+}
+
+// generateCode generates the Go package with the typed functions
+// for the keys of all the languages, the placeholders, plural and
+// gender variants of all the languages are taken into account
+func generateCode(
+	i18n *goeasyi18n.I18n,
+	sourceLanguage string,
+	packageName string,
+) ([]byte, error) {
+	keys, err := collectGeneratedKeys(i18n, sourceLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by goeasyi18n gen; DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	fmt.Fprintln(&b, `import "github.com/eduardolat/goeasyi18n"`)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// Translator translates the keys of the catalogs in a language")
+	fmt.Fprintln(&b, "type Translator struct {")
+	fmt.Fprintln(&b, "	i18n         *goeasyi18n.I18n")
+	fmt.Fprintln(&b, "	languageName string")
+	fmt.Fprintln(&b, "}")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// New creates a Translator for a language")
+	fmt.Fprintln(&b, "func New(i18n *goeasyi18n.I18n, languageName string) Translator {")
+	fmt.Fprintln(&b, "	return Translator{i18n: i18n, languageName: languageName}")
+	fmt.Fprintln(&b, "}")
+
+	for _, k := range keys {
+		params := []string{}
+		options := []string{}
+		if k.context != "" {
+			options = append(options, fmt.Sprintf("Context: %q", k.context))
+		}
+		if k.hasCount {
+			params = append(params, "count int")
+			options = append(options, "Count: &count")
+		}
+		if k.hasGender {
+			params = append(params, "gender string")
+			options = append(options, "Gender: &gender")
+		}
+		if len(k.placeholders) > 0 {
+			data := []string{}
+			for _, placeholder := range k.placeholders {
+				// The {{.Count}} of the pluralized keys is the count
+				if k.hasCount && placeholder == "Count" {
+					data = append(data, fmt.Sprintf("%q: count", placeholder))
+					continue
+				}
+				param := paramName(placeholder)
+				params = append(params, param+" any")
+				data = append(data, fmt.Sprintf("%q: %s", placeholder, param))
+			}
+			options = append(options, "Data: goeasyi18n.Data{"+strings.Join(data, ", ")+"}")
+		}
+
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "// %s translates the key %q", k.funcName, k.key)
+		if k.context != "" {
+			fmt.Fprintf(&b, " with the context %q", k.context)
+		}
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "//")
+		for _, text := range k.texts {
+			fmt.Fprintf(&b, "//\t%s\n", text)
+		}
+		fmt.Fprintf(&b, "func (t Translator) %s(%s) string {\n", k.funcName, strings.Join(params, ", "))
+		if len(options) == 0 {
+			fmt.Fprintf(&b, "	return t.i18n.Translate(t.languageName, %q)\n", k.key)
+		} else {
+			fmt.Fprintf(&b, "	return t.i18n.Translate(t.languageName, %q, goeasyi18n.Options{%s})\n", k.key, strings.Join(options, ", "))
+		}
+		fmt.Fprintln(&b, "}")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// collectGeneratedKeys merges the keys of all the languages
+// sorted by key and context
+func collectGeneratedKeys(
+	i18n *goeasyi18n.I18n,
+	sourceLanguage string,
+) ([]generatedKey, error) {
+	byID := map[string]*generatedKey{}
+	placeholders := map[string]map[string]bool{}
+	ids := []string{}
+
+	for _, languageName := range i18n.LanguageNames() {
+		for _, ts := range i18n.Translations(languageName) {
+			id := ts.Context + "\x04" + ts.Key
+			k, exists := byID[id]
+			if !exists {
+				k = &generatedKey{key: ts.Key, context: ts.Context}
+				byID[id] = k
+				placeholders[id] = map[string]bool{}
+				ids = append(ids, id)
+			}
+
+			for _, variantText := range translationTexts(ts) {
+				variant, text := variantText[0], variantText[1]
+				if isPluralVariant(variant) {
+					k.hasCount = true
+				}
+				if isGenderVariant(variant) {
+					k.hasGender = true
+				}
+				names, err := templatePlaceholders(text)
+				if err != nil {
+					return nil, fmt.Errorf("goeasyi18n: the key '%s' of '%s' has an invalid template: %w", ts.Key, languageName, err)
+				}
+				for _, name := range names {
+					placeholders[id][name] = true
+				}
+			}
+
+			// The texts of the source language are used as documentation
+			if languageName == sourceLanguage || len(k.texts) == 0 {
+				k.texts = documentedTexts(ts)
+			}
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if byID[ids[i]].key != byID[ids[j]].key {
+			return byID[ids[i]].key < byID[ids[j]].key
+		}
+		return byID[ids[i]].context < byID[ids[j]].context
+	})
+
+	keys := make([]generatedKey, 0, len(ids))
+	funcNames := map[string]string{}
+	for _, id := range ids {
+		k := byID[id]
+		k.funcName = exportedName(k.key + " " + k.context)
+		if other, exists := funcNames[k.funcName]; exists {
+			return nil, fmt.Errorf("goeasyi18n: the keys '%s' and '%s' generate the same function name '%s'", other, k.key, k.funcName)
+		}
+		funcNames[k.funcName] = k.key
+
+		for name := range placeholders[id] {
+			k.placeholders = append(k.placeholders, name)
+		}
+		sort.Strings(k.placeholders)
+		keys = append(keys, *k)
+	}
+
+	return keys, nil
+}
+
+// translationTexts returns the non empty texts of a translation
+// as variant name and text pairs, in the order of the fields
+func translationTexts(ts goeasyi18n.TranslateString) [][2]string {
+	texts := [][2]string{}
+	value := reflect.ValueOf(ts)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() != reflect.String || !isVariant(field.Name) {
+			continue
+		}
+		if text := value.Field(i).String(); text != "" {
+			texts = append(texts, [2]string{field.Name, text})
+		}
+	}
+	return texts
+}
+
+// documentedTexts returns the texts of a translation
+// as "Variant: text" lines for the doc comments
+func documentedTexts(ts goeasyi18n.TranslateString) []string {
+	texts := []string{}
+	for _, variantText := range translationTexts(ts) {
+		texts = append(texts, variantText[0]+": "+strings.ReplaceAll(variantText[1], "\n", " "))
+	}
+	return texts
+}
+
+func isVariant(name string) bool {
+	return name == "Default" || isPluralVariant(name) || isGenderVariant(name)
+}
+
+func isPluralVariant(name string) bool {
+	for _, plural := range pluralFormNames {
+		if strings.HasPrefix(name, plural) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGenderVariant(name string) bool {
+	for _, gender := range []string{"Male", "Female", "NonBinary"} {
+		if strings.HasSuffix(name, gender) {
+			return true
+		}
+	}
+	return false
+}
+
+// templatePlaceholders returns the top level fields used by a
+// template (like "Name" for "Hello {{.Name}}"), the fields inside
+// range and with blocks are ignored because the dot changes there
+func templatePlaceholders(text string) ([]string, error) {
+	tmpl, err := template.New("translation").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			found[n.Ident[0]] = true
+		case *parse.ChainNode:
+			walk(n.Node)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+		case *parse.WithNode:
+			walk(n.Pipe)
+		}
+	}
+	for _, associated := range tmpl.Templates() {
+		if associated.Tree != nil {
+			walk(associated.Tree.Root)
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// exportedName converts a key like "auth.sign_in" to a
+// Go exported identifier like "AuthSignIn"
+func exportedName(key string) string {
+	var sb strings.Builder
+	upperNext := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		sb.WriteRune(r)
+	}
+
+	name := sb.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Key" + name
+	}
+	return name
+}
+
+// paramName converts a placeholder like "EmailQty" to
+// a parameter name like "emailQty", the names used by the
+// generated code (like the goeasyi18n package) get a suffix
+func paramName(placeholder string) string {
+	runes := []rune(exportedName(placeholder))
+	runes[0] = unicode.ToLower(runes[0])
+	name := string(runes)
+	if token.IsKeyword(name) || name == "count" || name == "gender" || name == "t" || name == "goeasyi18n" {
+		name += "Value"
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func TestGen(t *testing.T) {
+	t.Run("should generate the typed functions", func(t *testing.T) {
+		code, stdout, stderr := runCommand("gen", "-dir", "testdata/translations")
+		if code != 0 {
+			t.Fatalf("Unexpected error: %s", stderr)
+		}
+
+		expected, err := os.ReadFile("testdata/gen.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stdout != string(expected) {
+			t.Errorf("expected %s; got %s", expected, stdout)
+		}
+	})
+
+	t.Run("should write the output file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "keys.go")
+		code, _, stderr := runCommand("gen", "-dir", "testdata/translations", "-pkg", "keys", "-out", output)
+		if code != 0 {
+			t.Fatalf("Unexpected error: %s", stderr)
+		}
+
+		written, err := os.ReadFile(output)
+		if err != nil || !strings.Contains(string(written), "package keys") {
+			t.Errorf("Unexpected result: %s %v", written, err)
+		}
+	})
+
+	t.Run("should fail with colliding names", func(t *testing.T) {
+		i18n := goeasyi18n.NewI18n()
+		i18n.AddLanguage("en", goeasyi18n.TranslateStrings{
+			{Key: "sign_in", Default: "Sign in"},
+			{Key: "sign.in", Default: "Sign in"},
+		})
+
+		_, err := generateCode(i18n, "en", "keys")
+		if err == nil || err.Error() != "goeasyi18n: the keys 'sign.in' and 'sign_in' generate the same function name 'SignIn'" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+	})
+
+	t.Run("should fail with invalid templates", func(t *testing.T) {
+		i18n := goeasyi18n.NewI18n()
+		i18n.AddLanguage("en", goeasyi18n.TranslateStrings{{Key: "hello", Default: "Hello {{.Name"}})
+
+		_, err := generateCode(i18n, "en", "keys")
+		if err == nil || !strings.HasPrefix(err.Error(), "goeasyi18n: the key 'hello' of 'en' has an invalid template") {
+			t.Errorf("Unexpected result: %v", err)
+		}
+	})
+}
+
+func TestTemplatePlaceholders(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Hello", []string{}},
+		{"Hello {{.Name}}", []string{"Name"}},
+		{"{{.B}} {{.A.Field}} {{if .C}}{{.D}}{{else}}{{.E}}{{end}}", []string{"A", "B", "C", "D", "E"}},
+		{"{{range .Items}}{{.Name}}{{end}}", []string{"Items"}},
+		{"{{with .User}}{{.Name}}{{end}} {{printf \"%d\" .Qty}}", []string{"Qty", "User"}},
+	}
+
+	for _, test := range tests {
+		got, err := templatePlaceholders(test.text)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("expected %v; got %v", test.expected, got)
+		}
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"hello", "Hello"},
+		{"hello_emails", "HelloEmails"},
+		{"auth.sign-in", "AuthSignIn"},
+		{"404_title", "Key404Title"},
+		{"You have no messages", "YouHaveNoMessages"},
+	}
+
+	for _, test := range tests {
+		if got := exportedName(test.key); got != test.expected {
+			t.Errorf("expected %s; got %s", test.expected, got)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := []struct {
+		placeholder string
+		expected    string
+	}{
+		{"EmailQty", "emailQty"},
+		{"Name", "name"},
+		{"type", "typeValue"},
+		{"Count", "countValue"},
+		{"T", "tValue"},
+		{"goeasyi18n", "goeasyi18nValue"},
+	}
+
+	for _, test := range tests {
+		if got := paramName(test.placeholder); got != test.expected {
+			t.Errorf("expected %s; got %s", test.expected, got)
+		}
+	}
+}
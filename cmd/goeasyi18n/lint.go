@@ -0,0 +1,265 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+func init() {
+	commands["lint"] = command{
+		description: "check the catalogs and exit with an error if there are problems",
+		run:         runLint,
+	}
+}
+
+// pluralFormNames are all the plural forms of a TranslateString
+var pluralFormNames = []string{"Zero", "One", "Two", "Few", "Many"}
+
+// defaultPluralForms are the forms of the DefaultPluralizationFunc
+var defaultPluralForms = []string{"One", "Many"}
+
+// pluralFormsFlag is a repeatable flag like "ar=Zero,One,Two,Few,Many"
+// with the plural forms that every pluralized key must have in a language
+type pluralFormsFlag map[string][]string
+
+func (f pluralFormsFlag) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name+"="+strings.Join(f[name], ","))
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func (f pluralFormsFlag) Set(value string) error {
+	languageName, forms, found := strings.Cut(value, "=")
+	if !found || languageName == "" || forms == "" {
+		return fmt.Errorf("the plural forms must be like 'ar=Zero,One,Two,Few,Many'")
+	}
+	for _, form := range strings.Split(forms, ",") {
+		form = strings.TrimSpace(form)
+		if !isPluralFormName(form) {
+			return fmt.Errorf("unknown plural form '%s'", form)
+		}
+		f[languageName] = append(f[languageName], form)
+	}
+	return nil
+}
+
+func runLint(args []string, stdout io.Writer, stderr io.Writer) int {
+	pluralForms := pluralFormsFlag{}
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	sourceLanguage := flags.String("lang", "en", "source language used to check the placeholders")
+	flags.Var(pluralForms, "plural", "plural forms of a language, like 'ar=Zero,One,Two,Few,Many' (repeatable, default: One,Many)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	i18n, problems, err := loadLintCatalogs(*dir)
+	if err != nil {
+		// The catalogs can't be loaded (like with syntax
+		// errors), so the other checks can't run
+		problems = append(problems, err.Error())
+	} else {
+		problems = append(problems, lintCatalogs(i18n, *sourceLanguage, pluralForms)...)
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintln(stdout, problem)
+	}
+
+	switch len(problems) {
+	case 0:
+		fmt.Fprintln(stdout, "no problems found")
+		return 0
+	case 1:
+		fmt.Fprintln(stdout, "1 problem found")
+	default:
+		fmt.Fprintf(stdout, "%d problems found\n", len(problems))
+	}
+	return 1
+}
+
+// loadLintCatalogs loads the catalogs once in strict mode collecting
+// the load errors, so the unknown fields and the duplicated keys (one
+// problem each) are returned as problems and the other checks can run
+// with the first definitions of the keys
+func loadLintCatalogs(dir string) (*goeasyi18n.I18n, []string, error) {
+	i18n := goeasyi18n.NewI18n(goeasyi18n.Config{
+		DisableConsistencyCheck: true,
+		DuplicateKeyPolicy:      goeasyi18n.DuplicateKeyPolicyError,
+		StrictLoading:           true,
+		CollectLoadErrors:       true,
+	})
+
+	err := i18n.LoadDir(dir)
+	var loadErrors *goeasyi18n.LoadErrors
+	if !errors.As(err, &loadErrors) {
+		return i18n, nil, err
+	}
+
+	var problems []string
+	for _, loadError := range loadErrors.Errors {
+		var duplicatesError *goeasyi18n.DuplicateKeysError
+		if !errors.As(loadError, &duplicatesError) {
+			problems = append(problems, loadError.Error())
+			continue
+		}
+		for _, duplicate := range duplicatesError.Duplicates {
+			single := &goeasyi18n.DuplicateKeysError{Duplicates: []goeasyi18n.DuplicateKey{duplicate}}
+			problems = append(problems, single.Error())
+		}
+	}
+	return i18n, problems, nil
+}
+
+// lintCatalogs checks the consistency of the languages, the templates,
+// the placeholders (compared with the source language) and the plural
+// forms, it returns the problems sorted and without repetitions
+func lintCatalogs(
+	i18n *goeasyi18n.I18n,
+	sourceLanguage string,
+	pluralForms map[string][]string,
+) []string {
+	found := map[string]bool{}
+	add := func(format string, args ...any) {
+		found[fmt.Sprintf(format, args...)] = true
+	}
+
+	languageNames := i18n.LanguageNames()
+	for _, languageName := range languageNames {
+		if i18n.Translations(languageName) == nil {
+			continue
+		}
+		_, inconsistencies := i18n.CheckLanguageConsistency(languageName)
+		for _, inconsistency := range inconsistencies {
+			add("%s", inconsistency)
+		}
+	}
+
+	sourcePlaceholders := map[string]map[string]bool{}
+	for _, ts := range i18n.Translations(sourceLanguage) {
+		placeholders, _ := translationPlaceholders(ts)
+		sourcePlaceholders[ts.Context+"\x04"+ts.Key] = placeholders
+	}
+
+	for _, languageName := range languageNames {
+		forms := pluralForms[languageName]
+		if len(forms) == 0 {
+			forms = defaultPluralForms
+		}
+
+		for _, ts := range i18n.Translations(languageName) {
+			prefix := fmt.Sprintf("goeasyi18n: %s: %s", languageName, describeKey(ts.Key, ts.Context))
+
+			placeholders, errs := translationPlaceholders(ts)
+			for _, err := range errs {
+				add("%s: %v", prefix, err)
+			}
+
+			for _, missing := range missingPluralForms(ts, forms) {
+				add("%s: the plural form '%s' is missing", prefix, missing)
+			}
+
+			source, hasSource := sourcePlaceholders[ts.Context+"\x04"+ts.Key]
+			if languageName == sourceLanguage || !hasSource {
+				continue
+			}
+			for name := range placeholders {
+				if !source[name] {
+					add("%s: the placeholder '%s' doesn't exist in '%s'", prefix, name, sourceLanguage)
+				}
+			}
+			for name := range source {
+				if !placeholders[name] {
+					add("%s: the placeholder '%s' of '%s' is not used", prefix, name, sourceLanguage)
+				}
+			}
+		}
+	}
+
+	problems := make([]string, 0, len(found))
+	for problem := range found {
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// translationPlaceholders returns the placeholders of all the variants
+// of a translation and the errors of the templates that can't be parsed
+func translationPlaceholders(ts goeasyi18n.TranslateString) (map[string]bool, []error) {
+	placeholders := map[string]bool{}
+	var errs []error
+	for _, variantText := range translationTexts(ts) {
+		names, err := templatePlaceholders(variantText[1])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("the %s template is invalid: %w", variantText[0], err))
+			continue
+		}
+		for _, name := range names {
+			placeholders[name] = true
+		}
+	}
+	return placeholders, errs
+}
+
+// missingPluralForms returns the plural forms that are missing in a
+// pluralized translation, the forms are checked for every gender that
+// has plural variants (like OneMale and ManyMale)
+func missingPluralForms(ts goeasyi18n.TranslateString, forms []string) []string {
+	texts := map[string]bool{}
+	genders := map[string]bool{}
+	for _, variantText := range translationTexts(ts) {
+		texts[variantText[0]] = true
+		if isPluralVariant(variantText[0]) {
+			for _, form := range pluralFormNames {
+				if strings.HasPrefix(variantText[0], form) {
+					genders[strings.TrimPrefix(variantText[0], form)] = true
+				}
+			}
+		}
+	}
+
+	genderNames := make([]string, 0, len(genders))
+	for gender := range genders {
+		genderNames = append(genderNames, gender)
+	}
+	sort.Strings(genderNames)
+
+	var missing []string
+	for _, gender := range genderNames {
+		for _, form := range forms {
+			if !texts[form+gender] {
+				missing = append(missing, form+gender)
+			}
+		}
+	}
+	return missing
+}
+
+func isPluralFormName(name string) bool {
+	for _, form := range pluralFormNames {
+		if form == name {
+			return true
+		}
+	}
+	return false
+}
+
+// describeKey returns the key (and its context if any)
+// quoted to be used in the messages
+func describeKey(key string, context string) string {
+	if context == "" {
+		return "key '" + key + "'"
+	}
+	return "key '" + key + "' with the context '" + context + "'"
+}
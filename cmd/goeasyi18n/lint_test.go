@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+// writeCatalogs writes the files in a temporary
+// directory and returns the directory
+func writeCatalogs(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLint(t *testing.T) {
+	t.Run("should report the problems and fail", func(t *testing.T) {
+		code, stdout, _ := runCommand("lint", "-dir", "testdata/lint")
+
+		expected := `goeasyi18n: es: key 'broken': the Default template is invalid: template: translation:1: unclosed action
+goeasyi18n: es: key 'emails': the placeholder 'Qty' of 'en' is not used
+goeasyi18n: es: key 'emails': the plural form 'Many' is missing
+goeasyi18n: es: key 'hello': the placeholder 'Name' of 'en' is not used
+goeasyi18n: es: key 'hello': the placeholder 'Nmae' doesn't exist in 'en'
+goeasyi18n: the language 'en' has the key 'english_only' that doesn't exist in 'es'
+goeasyi18n: the language 'es' has the key 'broken' that doesn't exist in 'en'
+7 problems found
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should pass with correct catalogs", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello {{.Name}}\n",
+			"es.json": `[{"Key": "hello", "Default": "Hola {{.Name}}"}]`,
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir)
+		if code != 0 || stdout != "no problems found\n" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should report every duplicated key and run the other checks", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello\n- Key: hello\n  Default: Hi\n- Key: bye\n- Key: bye\n",
+			"es.yaml": "- Key: hello\n  Default: Hola {{.Name}}\n- Key: bye\n",
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir)
+		expected := `goeasyi18n: the key 'hello' is defined more than once in the language 'en' (en.yaml:1:3; en.yaml:3:3)
+goeasyi18n: the key 'bye' is defined more than once in the language 'en' (en.yaml:5:3; en.yaml:6:3)
+goeasyi18n: es: key 'hello': the placeholder 'Name' doesn't exist in 'en'
+3 problems found
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should report the unknown fields", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: emails\n  One: One email\n  Mnay: Many emails\n",
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir)
+		expected := `goeasyi18n: en.yaml:3:3: key 'emails': unknown field 'Mnay'
+goeasyi18n: en: key 'emails': the plural form 'Many' is missing
+2 problems found
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should report the unknown fields and the duplicated keys together", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: hello\n  Default: Hello\n- Key: hello\n  Default: Hi\n",
+			"es.yaml": "- Key: hello\n  Default: Hola {{.Name}}\n  Mnay: x\n",
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir)
+		expected := `goeasyi18n: es.yaml:3:3: key 'hello': unknown field 'Mnay'
+goeasyi18n: the key 'hello' is defined more than once in the language 'en' (en.yaml:1:3; en.yaml:3:3)
+goeasyi18n: es: key 'hello': the placeholder 'Name' doesn't exist in 'en'
+3 problems found
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should stop with the syntax errors", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.json": `[{"Key": "hello",, }]`,
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir)
+		if code != 1 || !strings.HasSuffix(stdout, "\n1 problem found\n") {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should use the plural forms of the languages", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": "- Key: emails\n  One: One email\n  Many: Many emails\n",
+			"ar.yaml": "- Key: emails\n  One: One\n  Many: Many\n",
+		})
+
+		code, stdout, _ := runCommand("lint", "-dir", dir, "-plural", "ar=Zero,One,Two,Few,Many")
+		expected := `goeasyi18n: ar: key 'emails': the plural form 'Few' is missing
+goeasyi18n: ar: key 'emails': the plural form 'Two' is missing
+goeasyi18n: ar: key 'emails': the plural form 'Zero' is missing
+3 problems found
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+
+		code, _, stderr := runCommand("lint", "-dir", dir, "-plural", "ar=Zero,Other")
+		if code != 2 || !strings.Contains(stderr, "unknown plural form 'Other'") {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+}
+
+func TestMissingPluralForms(t *testing.T) {
+	tests := []struct {
+		ts       goeasyi18n.TranslateString
+		expected []string
+	}{
+		{goeasyi18n.TranslateString{Default: "Hello"}, nil},
+		{goeasyi18n.TranslateString{One: "One", Many: "Many"}, nil},
+		{goeasyi18n.TranslateString{Many: "Many"}, []string{"One"}},
+		{goeasyi18n.TranslateString{One: "One", Many: "Many", OneFemale: "One"}, []string{"ManyFemale"}},
+	}
+
+	for _, test := range tests {
+		got := missingPluralForms(test.ts, defaultPluralForms)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("expected %v; got %v", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Command goeasyi18n is the command-line tool of the goeasyi18n
+// library, it works with the catalogs of a directory (the same
+// layout that is loaded by I18n.LoadDir).
+//
+// Usage:
+//
+//	goeasyi18n <command> [flags]
+//
+// Run "goeasyi18n help" to see the available commands.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/eduardolat/goeasyi18n"
+)
+
+// command is a subcommand of the tool, run returns the exit code
+type command struct {
+	description string
+	run         func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+// commands are the subcommands by name, they are
+// registered by the init functions of their files
+var commands = map[string]command{}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return 0
+	}
+
+	cmd, exists := commands[args[0]]
+	if !exists {
+		fmt.Fprintf(stderr, "goeasyi18n: unknown command '%s'\n\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: goeasyi18n <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'goeasyi18n <command> -h' to see the flags of a command.")
+}
+
+// loadCatalogs loads the catalogs of a directory, the consistency
+// check is disabled because the commands report it themselves
+func loadCatalogs(dir string) (*goeasyi18n.I18n, error) {
+	i18n := goeasyi18n.NewI18n(goeasyi18n.Config{
+		DisableConsistencyCheck: true,
+	})
+	if err := i18n.LoadDir(dir); err != nil {
+		return nil, err
+	}
+	return i18n, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runCommand runs the tool with the arguments and
+// returns the exit code, stdout and stderr
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	t.Run("help should list the commands", func(t *testing.T) {
+		code, stdout, _ := runCommand("help")
+		if code != 0 || !strings.Contains(stdout, "gen ") {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("unknown commands should fail", func(t *testing.T) {
+		code, _, stderr := runCommand("xxx")
+		if code != 2 || !strings.Contains(stderr, "unknown command 'xxx'") {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+}
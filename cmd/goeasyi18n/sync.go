@@ -0,0 +1,715 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/eduardolat/goeasyi18n"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	commands["sync"] = command{
+		description: "add the missing keys of the source language to the other languages",
+		run:         runSync,
+	}
+}
+
+func runSync(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "translations", "directory with the catalogs")
+	sourceLanguage := flags.String("lang", "en", "language whose keys are added to the other languages")
+	empty := flags.Bool("empty", false, "add the missing keys without texts instead of copying the source texts")
+	prune := flags.Bool("prune", false, "remove the keys that are not in the source language instead of reporting them")
+	dryRun := flags.Bool("dry-run", false, "report the changes without writing the files, exit with 1 if there are changes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	files, skipped, err := findSyncFiles(*dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	for _, filePath := range skipped {
+		fmt.Fprintf(stdout, "skipped: %s (the %s files can't be synced)\n", filePath, strings.TrimPrefix(path.Ext(filePath), "."))
+	}
+
+	languageFiles := map[string][]*syncFile{}
+	for _, file := range files {
+		languageFiles[file.language] = append(languageFiles[file.language], file)
+	}
+	if len(languageFiles[*sourceLanguage]) == 0 {
+		fmt.Fprintf(stderr, "goeasyi18n: the source language '%s' doesn't have catalogs in '%s'\n", *sourceLanguage, *dir)
+		return 1
+	}
+
+	source := goeasyi18n.TranslateStrings{}
+	for _, file := range languageFiles[*sourceLanguage] {
+		source = append(source, file.translations...)
+	}
+	sourceIDs := map[string]bool{}
+	for _, ts := range source {
+		sourceIDs[syncID(ts)] = true
+	}
+
+	languageNames := make([]string, 0, len(languageFiles))
+	for languageName := range languageFiles {
+		if languageName != *sourceLanguage {
+			languageNames = append(languageNames, languageName)
+		}
+	}
+	sort.Strings(languageNames)
+
+	added, removed := 0, 0
+	for _, languageName := range languageNames {
+		present := map[string]bool{}
+		for _, file := range languageFiles[languageName] {
+			remove := map[string]bool{}
+			for _, ts := range file.translations {
+				id := syncID(ts)
+				present[id] = true
+				if sourceIDs[id] {
+					continue
+				}
+				if *prune {
+					remove[id] = true
+				} else {
+					fmt.Fprintf(stdout, "orphan: %s: %s (%s)\n", languageName, describeKey(ts.Key, ts.Context), file.path)
+				}
+			}
+			file.remove = remove
+		}
+
+		// The missing keys are added to the first file of the language
+		for _, ts := range source {
+			if ts.AliasOf != "" || present[syncID(ts)] {
+				continue
+			}
+			present[syncID(ts)] = true
+			file := languageFiles[languageName][0]
+			file.add = append(file.add, newSyncTranslation(ts, *empty))
+		}
+	}
+
+	for _, languageName := range languageNames {
+		for _, file := range languageFiles[languageName] {
+			if len(file.add) == 0 && len(file.remove) == 0 {
+				continue
+			}
+			added += len(file.add)
+			removed += len(file.remove)
+			fmt.Fprintf(stdout, "%s: added %s, removed %s\n", file.path, pluralKeys(len(file.add)), pluralKeys(len(file.remove)))
+
+			if *dryRun {
+				continue
+			}
+			if err := file.write(*dir); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+		}
+	}
+
+	if added == 0 && removed == 0 {
+		fmt.Fprintln(stdout, "all the languages are in sync")
+		return 0
+	}
+	fmt.Fprintf(stdout, "%s added and %s removed\n", pluralKeys(added), pluralKeys(removed))
+	if *dryRun {
+		return 1
+	}
+	return 0
+}
+
+// syncFile is a catalog file of a language and the changes
+// that sync makes to it
+type syncFile struct {
+	path         string
+	language     string
+	format       syncFormat
+	data         []byte
+	translations goeasyi18n.TranslateStrings
+	remove       map[string]bool
+	add          goeasyi18n.TranslateStrings
+}
+
+// syncFormat edits the catalogs of a file extension keeping the
+// format, the order and (when possible) the comments of the file
+type syncFormat struct {
+	load    func(data []byte) (goeasyi18n.TranslateStrings, error)
+	marshal func(translateStrings goeasyi18n.TranslateStrings) ([]byte, error)
+	edit    func(data []byte, remove map[string]bool, add goeasyi18n.TranslateStrings) ([]byte, error)
+}
+
+var syncFormats = map[string]syncFormat{
+	".json":       {goeasyi18n.LoadFromJsonBytes, goeasyi18n.MarshalJson, editJsonCatalog},
+	".yaml":       {goeasyi18n.LoadFromYamlBytes, goeasyi18n.MarshalYaml, editYamlCatalog},
+	".yml":        {goeasyi18n.LoadFromYamlBytes, goeasyi18n.MarshalYaml, editYamlCatalog},
+	".toml":       {goeasyi18n.LoadFromTomlBytes, goeasyi18n.MarshalToml, editTomlCatalog},
+	".properties": {goeasyi18n.LoadFromPropertiesBytes, goeasyi18n.MarshalProperties, editPropertiesCatalog},
+}
+
+// unsyncedExtensions are the extensions of the catalogs that are
+// loaded by I18n.LoadDir but can't be edited keeping their format
+var unsyncedExtensions = map[string]bool{".po": true, ".csv": true, ".ftl": true}
+
+// findSyncFiles loads the catalog files of the directory, the
+// language is inferred like I18n.LoadDir does it. The PO, CSV and
+// Fluent files are not synced, they are returned as skipped
+func findSyncFiles(dir string) ([]*syncFile, []string, error) {
+	var files []*syncFile
+	var skipped []string
+
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		extension := strings.ToLower(filepath.Ext(filePath))
+		format, isCatalog := syncFormats[extension]
+		if !isCatalog {
+			if unsyncedExtensions[extension] {
+				skipped = append(skipped, relativePath)
+			}
+			return nil
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		translations, err := format.load(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", relativePath, err)
+		}
+
+		files = append(files, &syncFile{
+			path:         relativePath,
+			language:     syncLanguageName(relativePath),
+			format:       format,
+			data:         data,
+			translations: translations,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files, skipped, nil
+}
+
+// syncLanguageName is the language of a catalog, the first directory
+// or the last dot separated part of the file name
+func syncLanguageName(relativePath string) string {
+	if dir, _, isNested := strings.Cut(relativePath, "/"); isNested {
+		return dir
+	}
+
+	name := strings.TrimSuffix(relativePath, path.Ext(relativePath))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		return name[dot+1:]
+	}
+	return name
+}
+
+// newSyncTranslation is the translation added for a missing key, it
+// has the source texts marked as needing review or no texts at all
+func newSyncTranslation(ts goeasyi18n.TranslateString, empty bool) goeasyi18n.TranslateString {
+	added := goeasyi18n.TranslateString{Key: ts.Key, Context: ts.Context}
+	if empty {
+		return added
+	}
+
+	target := reflect.ValueOf(&added).Elem()
+	for _, variantText := range translationTexts(ts) {
+		target.FieldByName(variantText[0]).SetString(variantText[1])
+	}
+	added.Status = goeasyi18n.StatusNeedsReview
+	return added
+}
+
+// write edits the file and checks that the result has the expected
+// translations, if it doesn't (for example when a TOML table would be
+// defined twice) the file is written again from its translations
+func (f *syncFile) write(dir string) error {
+	expected := goeasyi18n.TranslateStrings{}
+	for _, ts := range f.translations {
+		if !f.remove[syncID(ts)] {
+			expected = append(expected, ts)
+		}
+	}
+	expected = append(expected, f.add...)
+
+	data, err := f.format.edit(f.data, f.remove, f.add)
+	if err == nil {
+		var loaded goeasyi18n.TranslateStrings
+		loaded, err = f.format.load(data)
+		if err == nil && !sameSyncIDs(loaded, expected) {
+			err = fmt.Errorf("goeasyi18n: unexpected translations")
+		}
+	}
+	if err != nil {
+		data, err = f.format.marshal(expected)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, filepath.FromSlash(f.path)), data, 0o644)
+}
+
+func sameSyncIDs(a goeasyi18n.TranslateStrings, b goeasyi18n.TranslateStrings) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := map[string]int{}
+	for _, ts := range a {
+		ids[syncID(ts)]++
+	}
+	for _, ts := range b {
+		ids[syncID(ts)]--
+	}
+	for _, count := range ids {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// syncID identifies a translation by its key and context
+func syncID(ts goeasyi18n.TranslateString) string {
+	return ts.Context + "\x04" + ts.Key
+}
+
+func pluralKeys(n int) string {
+	if n == 1 {
+		return "1 key"
+	}
+	return fmt.Sprintf("%d keys", n)
+}
+
+// editJsonCatalog removes and appends the elements of the array, the
+// rest of the file is kept as it is and the new elements are written
+// like the last element (one per line or indented)
+func editJsonCatalog(data []byte, remove map[string]bool, add goeasyi18n.TranslateStrings) ([]byte, error) {
+	items, err := jsonArrayItems(data)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([][]byte, 0, len(add))
+	for _, ts := range add {
+		b := new(bytes.Buffer)
+		encoder := json.NewEncoder(b)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(ts); err != nil {
+			return nil, err
+		}
+		added = append(added, bytes.TrimSpace(b.Bytes()))
+	}
+
+	kept := []jsonArrayItem{}
+	for _, item := range items {
+		var ts goeasyi18n.TranslateString
+		if err := json.Unmarshal(data[item.start:item.end], &ts); err != nil {
+			return nil, err
+		}
+		if !remove[syncID(ts)] {
+			kept = append(kept, item)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	if len(kept) == 0 && len(added) == 0 {
+		b.WriteString("[]\n")
+		return b.Bytes(), nil
+	}
+	if len(items) == 0 {
+		// There is no layout to follow
+		b.WriteString("[\n")
+		for i, item := range added {
+			b.WriteString("  ")
+			if err := json.Indent(b, item, "  ", "  "); err != nil {
+				return nil, err
+			}
+			if i < len(added)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("]\n")
+		return b.Bytes(), nil
+	}
+
+	// The kept elements are written with the text that was before them
+	// (the comma and the indentation) except the first one
+	first, last := items[0], items[len(items)-1]
+	b.Write(data[:first.start])
+	for i, item := range kept {
+		if i > 0 {
+			b.Write(data[item.separator:item.start])
+		}
+		b.Write(data[item.start:item.end])
+	}
+
+	separator := jsonItemsSeparator(data, items)
+	lastItem := data[last.start:last.end]
+	for i, item := range added {
+		if len(kept) > 0 || i > 0 {
+			b.Write(separator)
+		}
+		if bytes.Contains(lastItem, []byte("\n")) {
+			lineIndent := separator[bytes.LastIndexByte(separator, '\n')+1:]
+			if err := json.Indent(b, item, string(lineIndent), jsonIndentUnit(lastItem, lineIndent)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		b.Write(jsonOneLine(item, bytes.Contains(lastItem, []byte(": "))))
+	}
+	b.Write(data[last.end:])
+
+	return b.Bytes(), nil
+}
+
+// jsonArrayItem is the position of an element of a JSON array,
+// separator is where the text between it and the previous one starts
+type jsonArrayItem struct {
+	separator int
+	start     int
+	end       int
+}
+
+// jsonArrayItems returns the positions of the elements of the array
+func jsonArrayItems(data []byte) ([]jsonArrayItem, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('[') {
+		return nil, fmt.Errorf("goeasyi18n: the JSON catalog is not an array")
+	}
+
+	var items []jsonArrayItem
+	for decoder.More() {
+		separator := int(decoder.InputOffset())
+		start := separator
+		for start < len(data) && bytes.IndexByte([]byte(", \t\r\n"), data[start]) >= 0 {
+			start++
+		}
+
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, jsonArrayItem{separator: separator, start: start, end: int(decoder.InputOffset())})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// jsonItemsSeparator returns the text between the last two elements,
+// or a comma and the text before the element if there is only one
+// (with a space if the element is spaced)
+func jsonItemsSeparator(data []byte, items []jsonArrayItem) []byte {
+	last := items[len(items)-1]
+	if len(items) > 1 {
+		return data[last.separator:last.start]
+	}
+
+	space := data[bytes.IndexByte(data, '[')+1 : last.start]
+	if len(space) == 0 && !bytes.Contains(data[last.start:last.end], []byte(": ")) {
+		return []byte(",")
+	}
+	if len(space) == 0 {
+		return []byte(", ")
+	}
+	return append([]byte(","), space...)
+}
+
+// jsonIndentUnit returns the indentation of the fields of an indented
+// element without the indentation of its line, "  " by default
+func jsonIndentUnit(item []byte, lineIndent []byte) string {
+	firstLine := item[bytes.IndexByte(item, '\n')+1:]
+	fieldIndent := firstLine[:len(firstLine)-len(bytes.TrimLeft(firstLine, " \t"))]
+	if bytes.HasPrefix(fieldIndent, lineIndent) && len(fieldIndent) > len(lineIndent) {
+		return string(fieldIndent[len(lineIndent):])
+	}
+	return "  "
+}
+
+// jsonOneLine writes a compact JSON value, with a space after
+// the colons and the commas if spaced is true
+func jsonOneLine(item []byte, spaced bool) []byte {
+	if !spaced {
+		return item
+	}
+
+	b := new(bytes.Buffer)
+	inString, escaped := false, false
+	for _, c := range item {
+		b.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ':' || c == ','):
+			b.WriteByte(' ')
+		}
+	}
+	return b.Bytes()
+}
+
+// editYamlCatalog removes and appends the elements of the sequence,
+// the comments of the existing elements are kept
+func editYamlCatalog(data []byte, remove map[string]bool, add goeasyi18n.TranslateStrings) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.SequenceNode, Tag: "!!seq"}},
+		}
+	}
+
+	sequence := document.Content[0]
+	if sequence.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("goeasyi18n: the catalog is not a list of translations")
+	}
+
+	kept := []*yaml.Node{}
+	for _, item := range sequence.Content {
+		var ts goeasyi18n.TranslateString
+		if err := item.Decode(&ts); err != nil {
+			return nil, err
+		}
+		if !remove[syncID(ts)] {
+			kept = append(kept, item)
+		}
+	}
+	for _, ts := range add {
+		item := &yaml.Node{}
+		if err := item.Encode(ts); err != nil {
+			return nil, err
+		}
+		kept = append(kept, item)
+	}
+	sequence.Content = kept
+	sequence.Style = 0
+
+	b := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// editTomlCatalog removes the tables of the removed translations
+// (with the comments right above them) and appends the new ones
+func editTomlCatalog(data []byte, remove map[string]bool, add goeasyi18n.TranslateStrings) ([]byte, error) {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	// Split the file in blocks that start with a table header,
+	// the first block has the lines before the first table
+	blocks := [][]string{{}}
+	inMultilineString := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !inMultilineString && strings.HasPrefix(trimmed, "[") {
+			current := blocks[len(blocks)-1]
+			comments := len(current)
+			for comments > 0 && strings.HasPrefix(strings.TrimSpace(current[comments-1]), "#") {
+				comments--
+			}
+			blocks[len(blocks)-1] = current[:comments]
+			blocks = append(blocks, append([]string{}, current[comments:]...))
+		}
+		if strings.Count(line, `"""`)%2 == 1 || strings.Count(line, `'''`)%2 == 1 {
+			inMultilineString = !inMultilineString
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+	}
+
+	var sb strings.Builder
+	for i, block := range blocks {
+		text := strings.Join(block, "")
+		if i > 0 {
+			translateStrings, err := goeasyi18n.LoadFromTomlBytes([]byte(text))
+			if err != nil {
+				return nil, err
+			}
+			if len(translateStrings) == 1 && remove[syncID(translateStrings[0])] {
+				continue
+			}
+		}
+		sb.WriteString(text)
+	}
+
+	return appendCatalog(sb.String(), add, goeasyi18n.MarshalToml)
+}
+
+// editPropertiesCatalog removes the properties of the removed
+// translations and appends the new ones
+func editPropertiesCatalog(data []byte, remove map[string]bool, add goeasyi18n.TranslateStrings) ([]byte, error) {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	// Group the properties in translations with the same
+	// rules as the loader, a property can span multiple lines
+	type propertiesEntry struct {
+		translation goeasyi18n.TranslateString
+		lines       []int
+	}
+	entries := []*propertiesEntry{}
+	indexes := map[string]int{}
+	lineEntries := make([]int, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		lineEntries[i] = -1
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			continue
+		}
+
+		first := i
+		for i+1 < len(lines) && endsWithContinuation(strings.TrimRight(lines[i], "\r\n")) {
+			i++
+		}
+		text := strings.Join(lines[first:i+1], "")
+
+		translateStrings, err := goeasyi18n.LoadFromPropertiesBytes([]byte(text))
+		if err != nil {
+			return nil, err
+		}
+		if len(translateStrings) != 1 {
+			continue
+		}
+		property := translateStrings[0]
+
+		index, exists := indexes[property.Key]
+		if !exists || overlapsFields(entries[index].translation, property) {
+			index = len(entries)
+			indexes[property.Key] = index
+			entries = append(entries, &propertiesEntry{
+				translation: goeasyi18n.TranslateString{Key: property.Key},
+			})
+		}
+		mergeFields(&entries[index].translation, property)
+		for j := first; j <= i; j++ {
+			entries[index].lines = append(entries[index].lines, j)
+			lineEntries[j] = index
+		}
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		if lineEntries[i] >= 0 && remove[syncID(entries[lineEntries[i]].translation)] {
+			continue
+		}
+		sb.WriteString(line)
+	}
+
+	return appendCatalog(sb.String(), add, goeasyi18n.MarshalProperties)
+}
+
+// endsWithContinuation checks if the line ends with an odd
+// number of backslashes
+func endsWithContinuation(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// overlapsFields checks if the property sets a field
+// that is already set in the translation
+func overlapsFields(ts goeasyi18n.TranslateString, property goeasyi18n.TranslateString) bool {
+	current := reflect.ValueOf(ts)
+	other := reflect.ValueOf(property)
+	for i := 0; i < other.NumField(); i++ {
+		if current.Type().Field(i).Name == "Key" {
+			continue
+		}
+		if !other.Field(i).IsZero() && !current.Field(i).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeFields copies the fields set by the property to the translation
+func mergeFields(ts *goeasyi18n.TranslateString, property goeasyi18n.TranslateString) {
+	current := reflect.ValueOf(ts).Elem()
+	other := reflect.ValueOf(property)
+	for i := 0; i < other.NumField(); i++ {
+		if !other.Field(i).IsZero() {
+			current.Field(i).Set(other.Field(i))
+		}
+	}
+}
+
+// appendCatalog appends the new translations to the
+// contents of a file, separated by an empty line
+func appendCatalog(
+	contents string,
+	add goeasyi18n.TranslateStrings,
+	marshal func(goeasyi18n.TranslateStrings) ([]byte, error),
+) ([]byte, error) {
+	if len(add) == 0 {
+		return []byte(contents), nil
+	}
+
+	added, err := marshal(add)
+	if err != nil {
+		return nil, err
+	}
+
+	contents = strings.TrimRight(contents, "\r\n")
+	if strings.TrimSpace(contents) == "" {
+		return added, nil
+	}
+	return []byte(contents + "\n\n" + string(added)), nil
+}
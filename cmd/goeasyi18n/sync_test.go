@@ -0,0 +1,303 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// syncSource is the source catalog of the sync tests
+const syncSource = `# Greetings
+- Key: hello
+  Default: Hello
+- Key: bye
+  Default: Bye <b>{{.Name}}</b>
+- Key: open
+  Context: verb
+  Default: Open
+- Key: goodbye
+  AliasOf: bye
+`
+
+func readCatalog(t *testing.T, dir string, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func TestSync(t *testing.T) {
+	t.Run("should add the missing keys to json", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es.json": `[
+    {"Default": "Hola", "Key": "hello"},
+    {"Key": "goodbye", "Default": "Adiós"}
+]`,
+		})
+
+		code, stdout, _ := runCommand("sync", "-dir", dir)
+		expectedOutput := `es.json: added 2 keys, removed 0 keys
+2 keys added and 0 keys removed
+`
+		if code != 0 || stdout != expectedOutput {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+
+		expected := `[
+    {"Default": "Hola", "Key": "hello"},
+    {"Key": "goodbye", "Default": "Adiós"},
+    {"Key": "bye", "Default": "Bye <b>{{.Name}}</b>", "Status": "needs-review"},
+    {"Key": "open", "Context": "verb", "Default": "Open", "Status": "needs-review"}
+]`
+		if got := readCatalog(t, dir, "es.json"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("should keep the layout of the indented json", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es.json": `[
+	{
+		"Key": "old",
+		"Default": "Viejo"
+	},
+	{
+		"Key": "hello",
+		"Default": "Hola"
+	},
+	{
+		"Key": "bye",
+		"Default": "Adiós"
+	}
+]
+`,
+		})
+
+		code, _, _ := runCommand("sync", "-dir", dir, "-prune")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+
+		expected := `[
+	{
+		"Key": "hello",
+		"Default": "Hola"
+	},
+	{
+		"Key": "bye",
+		"Default": "Adiós"
+	},
+	{
+		"Key": "open",
+		"Context": "verb",
+		"Default": "Open",
+		"Status": "needs-review"
+	}
+]
+`
+		if got := readCatalog(t, dir, "es.json"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("should report the orphans without removing them", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es.json": `[{"Key": "hello", "Default": "Hola"}, {"Key": "bye", "Default": "Adiós"}, {"Key": "open", "Context": "verb", "Default": "Abrir"}, {"Key": "old", "Default": "Viejo"}]`,
+		})
+
+		code, stdout, _ := runCommand("sync", "-dir", dir)
+		expectedOutput := `orphan: es: key 'old' (es.json)
+all the languages are in sync
+`
+		if code != 0 || stdout != expectedOutput {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+	})
+
+	t.Run("should prune the orphans and keep the yaml comments", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es/messages.yaml": `# Saludos
+- Key: hello
+  Default: Hola # informal
+- Key: old
+  Default: Viejo
+- Key: bye
+  Default: Adiós
+`,
+		})
+
+		code, stdout, _ := runCommand("sync", "-dir", dir, "-prune", "-empty")
+		expectedOutput := `es/messages.yaml: added 1 key, removed 1 key
+1 key added and 1 key removed
+`
+		if code != 0 || stdout != expectedOutput {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+
+		expected := `# Saludos
+- Key: hello
+  Default: Hola # informal
+- Key: bye
+  Default: Adiós
+- Key: open
+  Context: verb
+`
+		if got := readCatalog(t, dir, "es/messages.yaml"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("should sync toml", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"fr.toml": `# Salutations
+[hello]
+Default = "Bonjour"
+
+# Old key
+[old]
+Default = "Vieux"
+
+[bye]
+Default = "Au revoir"
+`,
+		})
+
+		code, _, _ := runCommand("sync", "-dir", dir, "-prune")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+
+		expected := `# Salutations
+[hello]
+Default = "Bonjour"
+
+[bye]
+Default = "Au revoir"
+
+[[open]]
+Context = "verb"
+Default = "Open"
+Status = "needs-review"
+`
+		if got := readCatalog(t, dir, "fr.toml"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("should sync properties", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"de.properties": `# Grüße
+hello = Hallo
+old = Alt \
+  und lang
+old.Description = Old key
+bye = Tschüss
+`,
+		})
+
+		code, _, _ := runCommand("sync", "-dir", dir, "-prune")
+		if code != 0 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+
+		expected := `# Grüße
+hello = Hallo
+bye = Tschüss
+
+open.Context = verb
+open = Open
+open.Status = needs-review
+`
+		if got := readCatalog(t, dir, "de.properties"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("should rewrite the file when the new keys can't be appended", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es.toml": "[open]\nDefault = \"Abrir\"\n\n[hello]\nDefault = \"Hola\"\n\n[bye]\nDefault = \"Adiós\"\n",
+		})
+
+		code, _, _ := runCommand("sync", "-dir", dir)
+		if code != 0 {
+			t.Errorf("Unexpected result: %d", code)
+		}
+
+		expected := `[bye]
+Default = "Adiós"
+
+[hello]
+Default = "Hola"
+
+[[open]]
+Default = "Abrir"
+
+[[open]]
+Context = "verb"
+Default = "Open"
+Status = "needs-review"
+`
+		if got := readCatalog(t, dir, "es.toml"); got != expected {
+			t.Errorf("expected %s; got %s", expected, got)
+		}
+	})
+
+	t.Run("dry run should not write the files", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml": syncSource,
+			"es.json": `[]`,
+		})
+
+		code, stdout, _ := runCommand("sync", "-dir", dir, "-dry-run")
+		if code != 1 || stdout != "es.json: added 3 keys, removed 0 keys\n3 keys added and 0 keys removed\n" {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+		if got := readCatalog(t, dir, "es.json"); got != "[]" {
+			t.Errorf("Unexpected result: %s", got)
+		}
+	})
+
+	t.Run("should report the files that are not synced", func(t *testing.T) {
+		po := "msgid \"\"\nmsgstr \"\"\n\nmsgid \"hello\"\nmsgstr \"Hallo\"\n"
+		dir := writeCatalogs(t, map[string]string{
+			"en.yaml":      syncSource,
+			"es.json":      `[]`,
+			"de.po":        po,
+			"fr/app.ftl":   "hello = Bonjour\n",
+			"messages.csv": "Key,it\nhello,Ciao\n",
+		})
+
+		code, stdout, _ := runCommand("sync", "-dir", dir, "-dry-run")
+		expected := `skipped: de.po (the po files can't be synced)
+skipped: fr/app.ftl (the ftl files can't be synced)
+skipped: messages.csv (the csv files can't be synced)
+es.json: added 3 keys, removed 0 keys
+3 keys added and 0 keys removed
+`
+		if code != 1 || stdout != expected {
+			t.Errorf("Unexpected result: %d %s", code, stdout)
+		}
+		if got := readCatalog(t, dir, "de.po"); got != po {
+			t.Errorf("Unexpected result: %s", got)
+		}
+	})
+
+	t.Run("should fail without source catalogs", func(t *testing.T) {
+		dir := writeCatalogs(t, map[string]string{
+			"es.json": `[]`,
+		})
+
+		code, _, stderr := runCommand("sync", "-dir", dir)
+		if code != 1 || stderr == "" {
+			t.Errorf("Unexpected result: %d %s", code, stderr)
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package main
+
+import "github.com/eduardolat/goeasyi18n"
+
+func main() {
+	i18n := goeasyi18n.NewI18n()
+	key := "dynamic"
+
+	i18n.T("en", "hello")
+	i18n.Translate("en", "hello_emails", goeasyi18n.Options{Count: nil})
+	i18n.T("en", "open", goeasyi18n.Options{Context: "status"})
+	i18n.T("en", key)
+
+	es := i18n.NewLangTranslateFunc("es")
+	es("new_feature")
+
+	var fr = i18n.NewLangTranslateFunc("fr")
+	fr(`raw_key`)
+}
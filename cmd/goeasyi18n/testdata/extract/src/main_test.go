@@ -0,0 +1,5 @@
+package main
+
+func ignored(i18n interface{ T(string, string) string }) {
+	i18n.T("en", "only_in_tests")
+}
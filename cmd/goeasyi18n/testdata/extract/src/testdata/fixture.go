@@ -0,0 +1,7 @@
+package testdata
+
+import "github.com/eduardolat/goeasyi18n"
+
+func fixture(i18n *goeasyi18n.I18n) {
+	i18n.T("en", "testdata_key")
+}
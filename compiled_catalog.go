@@ -0,0 +1,312 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// compiledCatalogMagic starts the compiled catalogs, it is
+// followed by a byte with the version of the format
+const compiledCatalogMagic = "goeasyi18n"
+
+const compiledCatalogVersion = 2
+
+// compiledLanguage is an entry of the index of a compiled catalog,
+// the translations of every language are encoded separately so a
+// language can be decoded without decoding the others
+type compiledLanguage struct {
+	Name   string
+	Offset int // From the end of the index
+	Length int
+}
+
+// compiledLanguageData are the gob encoded data of a language: its
+// translations, the prebuilt index of the keys (see indexKeys) and
+// the parsed plural rule if the language has one
+type compiledLanguageData struct {
+	Translations TranslateStrings
+	Keys         map[string]int
+	PluralRule   *compiledPluralRule
+}
+
+// compiledPluralRule is a PluralRule with its parsed expression,
+// so it is not parsed again when the catalog is loaded
+type compiledPluralRule struct {
+	Expression string
+	Forms      []string
+	Root       *pluralNode
+}
+
+// errTruncatedCatalog is returned for the compiled catalogs
+// whose index points outside of the data
+var errTruncatedCatalog = errors.New("goeasyi18n: the compiled catalog is truncated")
+
+// MarshalCompiledCatalog serializes the translations of multiple
+// languages to a compiled catalog, a binary file that is loaded
+// much faster than the text formats because it doesn't need to be
+// parsed or validated, so it can be embedded with go:embed.
+//
+// The format is the magic "goeasyi18n", a version byte, the length
+// of the index (4 bytes big endian), the gob encoded index of the
+// languages and the gob encoded data of every language: the
+// translations, the index of the keys used by Translate and the
+// parsed plural rule (see MarshalCompiledCatalogWithPluralRules).
+func MarshalCompiledCatalog(
+	translations map[string]TranslateStrings,
+) ([]byte, error) {
+	return MarshalCompiledCatalogWithPluralRules(translations, nil)
+}
+
+// MarshalCompiledCatalogWithPluralRules is MarshalCompiledCatalog
+// with the plural rules of the languages, they are set with
+// SetPluralRule when the catalog is loaded with LoadCompiledCatalog
+func MarshalCompiledCatalogWithPluralRules(
+	translations map[string]TranslateStrings,
+	pluralRules map[string]*PluralRule,
+) ([]byte, error) {
+	return marshalCompiledCatalog(translations, nil, pluralRules)
+}
+
+// marshalCompiledCatalog writes the compiled catalog, the indexes
+// of the keys are built if they are not passed
+func marshalCompiledCatalog(
+	translations map[string]TranslateStrings,
+	keyIndexes map[string]map[string]int,
+	pluralRules map[string]*PluralRule,
+) ([]byte, error) {
+	languageNames := make([]string, 0, len(translations))
+	for languageName := range translations {
+		languageNames = append(languageNames, languageName)
+	}
+	sort.Strings(languageNames)
+
+	index := []compiledLanguage{}
+	var body bytes.Buffer
+	for _, languageName := range languageNames {
+		languageData := compiledLanguageData{
+			Translations: translations[languageName],
+			Keys:         keyIndexes[languageName],
+		}
+		if languageData.Keys == nil {
+			languageData.Keys = indexKeys(languageData.Translations)
+		}
+		if rule := pluralRules[languageName]; rule != nil {
+			languageData.PluralRule = &compiledPluralRule{
+				Expression: rule.expression,
+				Forms:      rule.forms,
+				Root:       rule.root,
+			}
+		}
+
+		offset := body.Len()
+		if err := gob.NewEncoder(&body).Encode(languageData); err != nil {
+			return nil, err
+		}
+		index = append(index, compiledLanguage{
+			Name:   languageName,
+			Offset: offset,
+			Length: body.Len() - offset,
+		})
+	}
+
+	var encodedIndex bytes.Buffer
+	if err := gob.NewEncoder(&encodedIndex).Encode(index); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(compiledCatalogMagic)
+	b.WriteByte(compiledCatalogVersion)
+	_ = binary.Write(&b, binary.BigEndian, uint32(encodedIndex.Len()))
+	b.Write(encodedIndex.Bytes())
+	b.Write(body.Bytes())
+	return b.Bytes(), nil
+}
+
+// ExportCompiledCatalog serializes the translations of all the loaded
+// languages to a compiled catalog with their indexes of the keys and
+// the plural rules set with SetPluralRule.
+func (t *I18n) ExportCompiledCatalog() ([]byte, error) {
+	return marshalCompiledCatalog(t.languages, t.keyIndexes, t.pluralRules)
+}
+
+// LoadFromCompiledCatalogBytes loads the translations of a compiled
+// catalog (see MarshalCompiledCatalog), only the languages passed
+// are decoded if any, otherwise all the languages are loaded.
+func LoadFromCompiledCatalogBytes(
+	data []byte,
+	languageNames ...string,
+) (map[string]TranslateStrings, error) {
+	languages, err := decodeCompiledCatalog(data, languageNames)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]TranslateStrings, len(languages))
+	for languageName, languageData := range languages {
+		translations[languageName] = languageData.Translations
+	}
+	return translations, nil
+}
+
+// decodeCompiledCatalog decodes the data of the languages of a
+// compiled catalog (all of them or only the languages passed)
+// and checks their indexes of the keys and plural rules
+func decodeCompiledCatalog(
+	data []byte,
+	languageNames []string,
+) (map[string]*compiledLanguageData, error) {
+	header := len(compiledCatalogMagic) + 1 + 4
+	if len(data) < header || string(data[:len(compiledCatalogMagic)]) != compiledCatalogMagic {
+		return nil, errors.New("goeasyi18n: the data is not a compiled catalog")
+	}
+	if version := data[len(compiledCatalogMagic)]; version != compiledCatalogVersion {
+		return nil, fmt.Errorf("goeasyi18n: the compiled catalog version %d is not supported, compile it again", version)
+	}
+
+	// The bounds are checked as uint64 so they can't overflow
+	indexLength := uint64(binary.BigEndian.Uint32(data[header-4 : header]))
+	if uint64(header)+indexLength > uint64(len(data)) {
+		return nil, errTruncatedCatalog
+	}
+	indexEnd := header + int(indexLength)
+	index := []compiledLanguage{}
+	if err := gob.NewDecoder(bytes.NewReader(data[header:indexEnd])).Decode(&index); err != nil {
+		return nil, fmt.Errorf("goeasyi18n: invalid compiled catalog: %w", err)
+	}
+	body := data[indexEnd:]
+
+	picked := map[string]bool{}
+	for _, languageName := range languageNames {
+		picked[languageName] = true
+	}
+
+	languages := map[string]*compiledLanguageData{}
+	for _, language := range index {
+		if len(picked) > 0 && !picked[language.Name] {
+			continue
+		}
+		if language.Offset < 0 || language.Length < 0 ||
+			uint64(language.Offset)+uint64(language.Length) > uint64(len(body)) {
+			return nil, errTruncatedCatalog
+		}
+
+		languageData := &compiledLanguageData{}
+		encoded := body[language.Offset : language.Offset+language.Length]
+		err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(languageData)
+		if err == nil {
+			err = languageData.check()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("goeasyi18n: invalid compiled catalog for the language '%s': %w", language.Name, err)
+		}
+		if languageData.Translations == nil {
+			languageData.Translations = TranslateStrings{}
+		}
+		languages[language.Name] = languageData
+	}
+
+	for _, languageName := range languageNames {
+		if _, exists := languages[languageName]; !exists {
+			return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist in the compiled catalog", languageName)
+		}
+	}
+
+	return languages, nil
+}
+
+// check verifies that the index of the keys points to the first
+// translation of every key and that the plural rule can be evaluated,
+// so a corrupted catalog is an error instead of wrong translations
+func (d *compiledLanguageData) check() error {
+	for i, ts := range d.Translations {
+		index, exists := d.Keys[translationID(ts.Key, ts.Context)]
+		if !exists || index > i {
+			return fmt.Errorf("the key %s is not indexed", describeKey(ts.Key, ts.Context))
+		}
+	}
+	for id, index := range d.Keys {
+		if index < 0 || index >= len(d.Translations) ||
+			translationID(d.Translations[index].Key, d.Translations[index].Context) != id {
+			return fmt.Errorf("the index of the keys is invalid")
+		}
+	}
+
+	if rule := d.PluralRule; rule != nil {
+		if len(rule.Forms) == 0 || !rule.Root.isValid() {
+			return fmt.Errorf("the plural rule '%s' is invalid", rule.Expression)
+		}
+		for _, form := range rule.Forms {
+			if !isPluralFormName(form) {
+				return fmt.Errorf("unknown plural form '%s'", form)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadFromCompiledCatalogFile loads the translations of a compiled
+// catalog file, see LoadFromCompiledCatalogBytes.
+func LoadFromCompiledCatalogFile(
+	file string,
+	languageNames ...string,
+) (map[string]TranslateStrings, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFromCompiledCatalogBytes(data, languageNames...)
+}
+
+// LoadFromCompiledCatalogFS loads the translations of a compiled
+// catalog file located within the provided filesystem (fs.FS), like
+// an embed.FS, see LoadFromCompiledCatalogBytes.
+func LoadFromCompiledCatalogFS(
+	fileSystem fs.FS,
+	file string,
+	languageNames ...string,
+) (map[string]TranslateStrings, error) {
+	data, err := readFileFromFS(fileSystem, file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFromCompiledCatalogBytes(data, languageNames...)
+}
+
+// LoadCompiledCatalog adds the languages of a compiled catalog
+// (all of them or only the languages passed) to the i18n object,
+// with their prebuilt indexes of the keys and their plural rules.
+//
+// The pluralization functions that are Go code are not part of the
+// catalog: set them with SetPluralizationFunc after loading it.
+func (t *I18n) LoadCompiledCatalog(data []byte, languageNames ...string) error {
+	languages, err := decodeCompiledCatalog(data, languageNames)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(languages))
+	for languageName := range languages {
+		names = append(names, languageName)
+	}
+	sort.Strings(names)
+
+	for _, languageName := range names {
+		languageData := languages[languageName]
+		t.addLanguage(languageName, languageData.Translations, languageData.Keys)
+		if rule := languageData.PluralRule; rule != nil {
+			t.SetPluralRule(languageName, &PluralRule{
+				expression: rule.Expression,
+				forms:      rule.Forms,
+				root:       rule.Root,
+			})
+		}
+	}
+	return nil
+}
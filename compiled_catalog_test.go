@@ -0,0 +1,268 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCompiledCatalog(t *testing.T) {
+	translations := map[string]TranslateStrings{
+		"en": {
+			{Key: "hello", Default: "Hello {{.Name}}", Description: "Greeting", Tags: []string{"home"}},
+			{Key: "emails", One: "One email", Many: "{{.Count}} emails", MaxLength: 20},
+			{Key: "open", Context: "verb", Default: "Open", Status: StatusApproved},
+		},
+		"es": {
+			{Key: "hello", Default: "Hola {{.Name}}", SourceHash: "abc"},
+			{Key: "goodbye", AliasOf: "hello", Deprecated: true},
+		},
+		"fr": {},
+	}
+
+	compiled, err := MarshalCompiledCatalog(translations)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("should load all the languages", func(t *testing.T) {
+		got, err := LoadFromCompiledCatalogBytes(compiled)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, translations) {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+
+	t.Run("should load only the languages passed", func(t *testing.T) {
+		got, err := LoadFromCompiledCatalogBytes(compiled, "es")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(got) != 1 || !reflect.DeepEqual(got["es"], translations["es"]) {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+
+	t.Run("should fail with a missing language", func(t *testing.T) {
+		_, err := LoadFromCompiledCatalogBytes(compiled, "de")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("should fail with invalid data", func(t *testing.T) {
+		tests := map[string][]byte{
+			"not a catalog": []byte(`[{"Key": "hello"}]`),
+			"version":       append([]byte("goeasyi18n\x09"), compiled[11:]...),
+			"truncated":     compiled[:len(compiled)-10],
+			"index length":  append([]byte("goeasyi18n\x02\xff\xff\xff\xff"), compiled[15:]...),
+		}
+		for name, data := range tests {
+			if _, err := LoadFromCompiledCatalogBytes(data); err == nil {
+				t.Errorf("Expected error, got nil: %s", name)
+			}
+		}
+	})
+
+	t.Run("should fail with offsets out of the data", func(t *testing.T) {
+		tests := []compiledLanguage{
+			{Name: "en", Offset: 0, Length: math.MaxInt},
+			{Name: "en", Offset: math.MaxInt, Length: math.MaxInt},
+			{Name: "en", Offset: -1, Length: 1},
+		}
+		for _, language := range tests {
+			var index bytes.Buffer
+			if err := gob.NewEncoder(&index).Encode([]compiledLanguage{language}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data := []byte(compiledCatalogMagic)
+			data = append(data, compiledCatalogVersion)
+			data = binary.BigEndian.AppendUint32(data, uint32(index.Len()))
+			data = append(data, index.Bytes()...)
+
+			if _, err := LoadFromCompiledCatalogBytes(data); err != errTruncatedCatalog {
+				t.Errorf("expected %v; got %v", errTruncatedCatalog, err)
+			}
+		}
+	})
+
+	t.Run("should load from a file and a filesystem", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "catalog.bin")
+		if err := os.WriteFile(file, compiled, 0o644); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got, err := LoadFromCompiledCatalogFile(file)
+		if err != nil || len(got) != 3 {
+			t.Errorf("Unexpected result: %v %v", got, err)
+		}
+
+		fileSystem := fstest.MapFS{"translations/catalog.bin": {Data: compiled}}
+		got, err = LoadFromCompiledCatalogFS(fileSystem, "translations/catalog.bin", "en")
+		if err != nil || len(got) != 1 || len(got["en"]) != 3 {
+			t.Errorf("Unexpected result: %v %v", got, err)
+		}
+	})
+
+	t.Run("should export and load an i18n object", func(t *testing.T) {
+		source := NewI18n()
+		source.AddLanguage("en", translations["en"])
+		data, err := source.ExportCompiledCatalog()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		i18n := NewI18n()
+		if err := i18n.LoadCompiledCatalog(data); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		got := i18n.T("en", "hello", Options{Data: map[string]string{"Name": "John"}})
+		if got != "Hello John" {
+			t.Errorf("expected %s; got %s", "Hello John", got)
+		}
+	})
+
+	t.Run("should keep the index of the keys and the plural rules", func(t *testing.T) {
+		rule, err := ParsePluralRule("n == 1 ? 0 : n == 0 ? 1 : 2", "One", "Zero", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		source := NewI18n()
+		source.AddLanguage("en", TranslateStrings{
+			{Key: "emails", One: "One email", Zero: "No emails", Many: "{{.Count}} emails"},
+			{Key: "open", Context: "verb", Default: "Open"},
+			{Key: "emails", Default: "Duplicated"},
+		})
+		source.SetPluralRule("en", rule)
+		data, err := source.ExportCompiledCatalog()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		i18n := NewI18n()
+		if err := i18n.LoadCompiledCatalog(data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(i18n.keyIndexes["en"], source.keyIndexes["en"]) {
+			t.Errorf("Unexpected result: %v", i18n.keyIndexes["en"])
+		}
+		if i18n.pluralRules["en"].String() != rule.String() {
+			t.Errorf("expected %s; got %s", rule, i18n.pluralRules["en"])
+		}
+		for count, expected := range map[int]string{0: "No emails", 1: "One email", 5: "5 emails"} {
+			got := i18n.T("en", "emails", Options{Count: &count, Data: map[string]int{"Count": count}})
+			if got != expected {
+				t.Errorf("expected %s; got %s", expected, got)
+			}
+		}
+		if got := i18n.T("en", "open", Options{Context: "verb"}); got != "Open" {
+			t.Errorf("expected %s; got %s", "Open", got)
+		}
+	})
+
+	t.Run("should fail with an invalid index of the keys or plural rule", func(t *testing.T) {
+		tests := map[string]compiledLanguageData{
+			"missing key": {
+				Translations: TranslateStrings{{Key: "hello"}, {Key: "bye"}},
+				Keys:         map[string]int{"hello": 0},
+			},
+			"wrong index": {
+				Translations: TranslateStrings{{Key: "hello"}, {Key: "bye"}},
+				Keys:         map[string]int{"hello": 1, "bye": 0},
+			},
+			"index out of the translations": {
+				Translations: TranslateStrings{{Key: "hello"}},
+				Keys:         map[string]int{"hello": 0, "bye": 5},
+			},
+			"invalid plural rule": {
+				Translations: TranslateStrings{{Key: "hello"}},
+				Keys:         map[string]int{"hello": 0},
+				PluralRule:   &compiledPluralRule{Expression: "n == 1", Forms: []string{"One"}, Root: &pluralNode{Op: "=="}},
+			},
+			"invalid plural form": {
+				Translations: TranslateStrings{{Key: "hello"}},
+				Keys:         map[string]int{"hello": 0},
+				PluralRule:   &compiledPluralRule{Expression: "n", Forms: []string{"Other"}, Root: &pluralNode{Op: "n"}},
+			},
+		}
+		for name, languageData := range tests {
+			var encoded, index bytes.Buffer
+			if err := gob.NewEncoder(&encoded).Encode(languageData); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			language := compiledLanguage{Name: "en", Offset: 0, Length: encoded.Len()}
+			if err := gob.NewEncoder(&index).Encode([]compiledLanguage{language}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data := []byte(compiledCatalogMagic)
+			data = append(data, compiledCatalogVersion)
+			data = binary.BigEndian.AppendUint32(data, uint32(index.Len()))
+			data = append(data, index.Bytes()...)
+			data = append(data, encoded.Bytes()...)
+
+			if err := NewI18n().LoadCompiledCatalog(data); err == nil {
+				t.Errorf("Expected error, got nil: %s", name)
+			}
+		}
+	})
+}
+
+// BenchmarkCompiledCatalog compares the loading of a compiled
+// catalog with the text formats it is compiled from
+func BenchmarkCompiledCatalog(b *testing.B) {
+	translateStrings := TranslateStrings{}
+	for i := 0; i < 5000; i++ {
+		translateStrings = append(translateStrings, TranslateString{
+			Key:         fmt.Sprintf("key_%d", i),
+			Default:     "Hello {{.Name}}, you have new messages",
+			One:         "You have one message",
+			Many:        "You have {{.Count}} messages",
+			Description: "Greeting of the home page",
+		})
+	}
+
+	compiled, err := MarshalCompiledCatalog(map[string]TranslateStrings{"en": translateStrings})
+	if err != nil {
+		b.Fatalf("Unexpected error: %v", err)
+	}
+	jsonBytes, err := MarshalJson(translateStrings)
+	if err != nil {
+		b.Fatalf("Unexpected error: %v", err)
+	}
+	yamlBytes, err := MarshalYaml(translateStrings)
+	if err != nil {
+		b.Fatalf("Unexpected error: %v", err)
+	}
+
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadFromCompiledCatalogBytes(compiled); err != nil {
+				b.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	})
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadFromJsonBytes(jsonBytes); err != nil {
+				b.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	})
+
+	b.Run("yaml", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadFromYamlBytes(yamlBytes); err != nil {
+				b.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	})
+}
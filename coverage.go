@@ -0,0 +1,153 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// CoverageKey is a key of the source language in a coverage report,
+// Variants are the missing variants in LanguageCoverage.MissingVariants
+type CoverageKey struct {
+	Key      string
+	Context  string
+	Variants []string
+}
+
+// LanguageCoverage is the translation coverage of
+// a language compared with the source language
+type LanguageCoverage struct {
+	LanguageName   string
+	TotalKeys      int     // The keys of the source language
+	TranslatedKeys int     // The keys that have a text in the language
+	Percent        float64 // TranslatedKeys of TotalKeys, from 0 to 100
+
+	// MissingKeys are the keys without a text in the language
+	MissingKeys []CoverageKey
+	// MissingVariants are the translated keys that don't have
+	// some variants (like One or Female) of the source language
+	MissingVariants []CoverageKey
+	// IdenticalKeys are the translated keys that have the same
+	// texts as the source language, they are likely untranslated
+	IdenticalKeys []CoverageKey
+
+	SourceWords  int // The words of the texts of the source language
+	MissingWords int // The source words of the missing keys and variants
+}
+
+// Coverage returns the translation coverage of all the languages
+// (except the source language) sorted by language name. The aliases
+// are not counted, and the translations without texts are missing,
+// like the fuzzy translations with the FuzzyAsMissing config
+func (t *I18n) Coverage(sourceLanguageName string) []LanguageCoverage {
+	sources := TranslateStrings{}
+	sourceWords := 0
+	for _, ts := range t.languages[sourceLanguageName] {
+		if ts.AliasOf != "" {
+			continue
+		}
+		sources = append(sources, ts)
+		for _, text := range coverageTexts(ts) {
+			sourceWords += countWords(text)
+		}
+	}
+	sources = sortTranslateStrings(sources)
+
+	coverages := []LanguageCoverage{}
+	for _, languageName := range t.LanguageNames() {
+		if languageName == sourceLanguageName {
+			continue
+		}
+
+		translations := map[string]TranslateString{}
+		for _, ts := range t.languages[languageName] {
+			if ts.AliasOf == "" && t.isUsable(ts) {
+				translations[translationID(ts.Key, ts.Context)] = ts
+			}
+		}
+
+		coverage := LanguageCoverage{
+			LanguageName:    languageName,
+			TotalKeys:       len(sources),
+			Percent:         100,
+			MissingKeys:     []CoverageKey{},
+			MissingVariants: []CoverageKey{},
+			IdenticalKeys:   []CoverageKey{},
+			SourceWords:     sourceWords,
+		}
+
+		for _, source := range sources {
+			sourceTexts := coverageTexts(source)
+			texts := coverageTexts(translations[translationID(source.Key, source.Context)])
+			key := CoverageKey{Key: source.Key, Context: source.Context}
+
+			if len(texts) == 0 {
+				coverage.MissingKeys = append(coverage.MissingKeys, key)
+				for _, text := range sourceTexts {
+					coverage.MissingWords += countWords(text)
+				}
+				continue
+			}
+			coverage.TranslatedKeys++
+
+			identical := true
+			for _, variant := range variantNames {
+				sourceText, exists := sourceTexts[variant]
+				if !exists {
+					continue
+				}
+				text, translated := texts[variant]
+				if !translated {
+					key.Variants = append(key.Variants, variant)
+					coverage.MissingWords += countWords(sourceText)
+				}
+				if text != sourceText {
+					identical = false
+				}
+			}
+			if len(key.Variants) > 0 {
+				coverage.MissingVariants = append(coverage.MissingVariants, key)
+			} else if identical && len(sourceTexts) > 0 {
+				coverage.IdenticalKeys = append(coverage.IdenticalKeys, key)
+			}
+		}
+
+		if coverage.TotalKeys > 0 {
+			coverage.Percent = float64(coverage.TranslatedKeys) * 100 / float64(coverage.TotalKeys)
+		}
+		coverages = append(coverages, coverage)
+	}
+
+	return coverages
+}
+
+// coverageTexts returns the non-empty texts of a translation by variant
+func coverageTexts(translateString TranslateString) map[string]string {
+	texts := map[string]string{}
+	reflected := reflect.ValueOf(translateString)
+	for _, variant := range variantNames {
+		if text := reflected.FieldByName(variant).String(); text != "" {
+			texts[variant] = text
+		}
+	}
+	return texts
+}
+
+// templateActionRegexp matches the template actions,
+// they are not counted as words of the texts
+var templateActionRegexp = regexp.MustCompile(`{{.*?}}`)
+
+// countWords counts the words of a text (separated by spaces) without
+// its template actions and punctuation, the way vendors quote them
+func countWords(text string) int {
+	words := 0
+	for _, field := range strings.Fields(templateActionRegexp.ReplaceAllString(text, " ")) {
+		if strings.IndexFunc(field, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsNumber(r)
+		}) >= 0 {
+			words++
+		}
+	}
+	return words
+}
@@ -0,0 +1,95 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	newI18n := func(config ...Config) *I18n {
+		i18n := NewI18n(config...)
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "hello", Default: "Hello {{.Name}}, welcome"},
+			{Key: "bye", Default: "Bye"},
+			{Key: "emails", One: "You have one email", Many: "You have {{.Count}} emails"},
+			{Key: "open", Context: "verb", Default: "Open"},
+			{Key: "ok", Default: "OK"},
+			{Key: "goodbye", AliasOf: "bye"},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "hello", Default: "Hola {{.Name}}, bienvenido", Status: StatusFuzzy},
+			{Key: "bye", Default: ""},
+			{Key: "emails", Many: "Tienes {{.Count}} correos"},
+			{Key: "open", Context: "verb", Default: "Abrir"},
+			{Key: "ok", Default: "OK"},
+			{Key: "old", Default: "Viejo"},
+		})
+		i18n.AddLanguage("fr", TranslateStrings{})
+		return i18n
+	}
+
+	t.Run("should report the coverage of the languages", func(t *testing.T) {
+		expected := []LanguageCoverage{
+			{
+				LanguageName:    "es",
+				TotalKeys:       5,
+				TranslatedKeys:  4,
+				Percent:         80,
+				MissingKeys:     []CoverageKey{{Key: "bye"}},
+				MissingVariants: []CoverageKey{{Key: "emails", Variants: []string{"One"}}},
+				IdenticalKeys:   []CoverageKey{{Key: "ok"}},
+				SourceWords:     12,
+				MissingWords:    5,
+			},
+			{
+				LanguageName:    "fr",
+				TotalKeys:       5,
+				Percent:         0,
+				MissingKeys:     []CoverageKey{{Key: "bye"}, {Key: "emails"}, {Key: "hello"}, {Key: "ok"}, {Key: "open", Context: "verb"}},
+				MissingVariants: []CoverageKey{},
+				IdenticalKeys:   []CoverageKey{},
+				SourceWords:     12,
+				MissingWords:    12,
+			},
+		}
+
+		if got := newI18n().Coverage("en"); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("fuzzy translations should be missing with FuzzyAsMissing", func(t *testing.T) {
+		got := newI18n(Config{FuzzyAsMissing: true}).Coverage("en")
+
+		expected := []CoverageKey{{Key: "bye"}, {Key: "hello"}}
+		if got[0].TranslatedKeys != 3 || !reflect.DeepEqual(got[0].MissingKeys, expected) {
+			t.Errorf("Unexpected result: %+v", got[0])
+		}
+	})
+
+	t.Run("languages without source keys should be complete", func(t *testing.T) {
+		got := newI18n().Coverage("de")
+
+		if len(got) != 3 || got[0].Percent != 100 || got[0].TotalKeys != 0 {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+}
+
+func TestCountWords(t *testing.T) {
+	tests := map[string]int{
+		"":                         0,
+		"Hello":                    1,
+		"  Hello   world ":         2,
+		"Hello {{.Name}}, welcome": 2,
+		"{{.Count}} emails":        1,
+		"Hello - world!":           2,
+		"You have {{ .Count }}new": 3,
+	}
+
+	for text, expected := range tests {
+		if got := countWords(text); got != expected {
+			t.Errorf("expected %d; got %d for %q", expected, got, text)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package goeasyi18n
+
+import "sort"
+
+// ChangeType is the kind of a CatalogChange
+type ChangeType string
+
+const (
+	// ChangeAdded is a key that only exists in the new catalogs
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved is a key that only exists in the old catalogs
+	ChangeRemoved ChangeType = "removed"
+	// ChangeModified is a variant or metadata field of a key
+	// whose value is different in the new catalogs
+	ChangeModified ChangeType = "modified"
+)
+
+// CatalogChange is a difference between two versions of the catalogs,
+// the Field is empty when the whole key was added or removed
+type CatalogChange struct {
+	Type         ChangeType
+	LanguageName string
+	Key          string
+	Context      string
+	Field        string // The variant (like "Default" or "One") or the metadata field
+	OldValue     string
+	NewValue     string
+}
+
+// DiffCatalogs compares two versions of the translations of the
+// languages and returns the added, removed and modified keys and
+// fields, sorted by language, key, context and field. The keys of
+// the added and removed languages are listed as added or removed
+func DiffCatalogs(
+	oldTranslations map[string]TranslateStrings,
+	newTranslations map[string]TranslateStrings,
+) []CatalogChange {
+	languageNames := []string{}
+	for languageName := range oldTranslations {
+		languageNames = append(languageNames, languageName)
+	}
+	for languageName := range newTranslations {
+		if _, exists := oldTranslations[languageName]; !exists {
+			languageNames = append(languageNames, languageName)
+		}
+	}
+	sort.Strings(languageNames)
+
+	changes := []CatalogChange{}
+	for _, languageName := range languageNames {
+		changes = append(changes, diffLanguage(
+			languageName,
+			oldTranslations[languageName],
+			newTranslations[languageName],
+		)...)
+	}
+	return changes
+}
+
+// Diff compares the loaded translations with the ones of a newer
+// version of the catalogs (like a vendor delivery) using DiffCatalogs
+func (t *I18n) Diff(newer *I18n) []CatalogChange {
+	return DiffCatalogs(t.languages, newer.languages)
+}
+
+// diffLanguage compares two versions of the translations of a
+// language, the keys that are defined more than once are compared
+// by their first definition (the one used by Translate)
+func diffLanguage(
+	languageName string,
+	oldTranslateStrings TranslateStrings,
+	newTranslateStrings TranslateStrings,
+) []CatalogChange {
+	olds := firstDefinitions(oldTranslateStrings)
+	news := firstDefinitions(newTranslateStrings)
+
+	all := TranslateStrings{}
+	for _, ts := range olds {
+		all = append(all, ts)
+	}
+	for id, ts := range news {
+		if _, exists := olds[id]; !exists {
+			all = append(all, ts)
+		}
+	}
+	all = sortTranslateStrings(all)
+
+	fieldNames := append(append([]string{}, variantNames...), extraFieldNames...)
+
+	changes := []CatalogChange{}
+	for _, ts := range all {
+		id := translationID(ts.Key, ts.Context)
+		oldTs, inOld := olds[id]
+		newTs, inNew := news[id]
+
+		change := CatalogChange{
+			LanguageName: languageName,
+			Key:          ts.Key,
+			Context:      ts.Context,
+		}
+		if !inOld {
+			change.Type = ChangeAdded
+			changes = append(changes, change)
+			continue
+		}
+		if !inNew {
+			change.Type = ChangeRemoved
+			changes = append(changes, change)
+			continue
+		}
+
+		for _, fieldName := range fieldNames {
+			if fieldName == "Context" {
+				continue
+			}
+			oldValue := csvFieldValue(oldTs, fieldName)
+			newValue := csvFieldValue(newTs, fieldName)
+			if oldValue == newValue {
+				continue
+			}
+
+			fieldChange := change
+			fieldChange.Field = fieldName
+			fieldChange.OldValue = oldValue
+			fieldChange.NewValue = newValue
+			switch {
+			case oldValue == "":
+				fieldChange.Type = ChangeAdded
+			case newValue == "":
+				fieldChange.Type = ChangeRemoved
+			default:
+				fieldChange.Type = ChangeModified
+			}
+			changes = append(changes, fieldChange)
+		}
+	}
+	return changes
+}
+
+// firstDefinitions returns the first definition of every key and
+// context of the translations by their translation ID
+func firstDefinitions(translateStrings TranslateStrings) map[string]TranslateString {
+	definitions := make(map[string]TranslateString, len(translateStrings))
+	for _, ts := range translateStrings {
+		id := translationID(ts.Key, ts.Context)
+		if _, exists := definitions[id]; !exists {
+			definitions[id] = ts
+		}
+	}
+	return definitions
+}
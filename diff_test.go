@@ -0,0 +1,92 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	t.Run("should list the changes of the keys and fields", func(t *testing.T) {
+		oldTranslations := map[string]TranslateStrings{
+			"es": {
+				{Key: "hello", Default: "Hola"},
+				{Key: "bye", Default: "Adiós"},
+				{Key: "emails", Many: "Tienes {{.Count}} correos", Status: StatusFuzzy},
+				{Key: "open", Context: "verb", Default: "Abrir", Tags: []string{"menu"}},
+			},
+			"fr": {
+				{Key: "hello", Default: "Bonjour"},
+			},
+		}
+		newTranslations := map[string]TranslateStrings{
+			"es": {
+				{Key: "hello", Default: "¡Hola!"},
+				{Key: "emails", One: "Tienes un correo", Many: "Tienes {{.Count}} correos"},
+				{Key: "open", Context: "verb", Default: "Abrir", Tags: []string{"menu", "toolbar"}},
+				{Key: "open", Default: "Abierto"},
+			},
+			"de": {
+				{Key: "hello", Default: "Hallo"},
+			},
+		}
+
+		expected := []CatalogChange{
+			{Type: ChangeAdded, LanguageName: "de", Key: "hello"},
+			{Type: ChangeRemoved, LanguageName: "es", Key: "bye"},
+			{Type: ChangeAdded, LanguageName: "es", Key: "emails", Field: "One", NewValue: "Tienes un correo"},
+			{Type: ChangeRemoved, LanguageName: "es", Key: "emails", Field: "Status", OldValue: "fuzzy"},
+			{Type: ChangeModified, LanguageName: "es", Key: "hello", Field: "Default", OldValue: "Hola", NewValue: "¡Hola!"},
+			{Type: ChangeAdded, LanguageName: "es", Key: "open"},
+			{Type: ChangeModified, LanguageName: "es", Key: "open", Context: "verb", Field: "Tags", OldValue: "menu", NewValue: "menu, toolbar"},
+			{Type: ChangeRemoved, LanguageName: "fr", Key: "hello"},
+		}
+		if got := DiffCatalogs(oldTranslations, newTranslations); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("equal catalogs should have no changes", func(t *testing.T) {
+		translations := map[string]TranslateStrings{
+			"en": {{Key: "hello", Default: "Hello", Tags: []string{}}},
+		}
+		if got := DiffCatalogs(translations, map[string]TranslateStrings{
+			"en": {{Key: "hello", Default: "Hello"}},
+		}); len(got) != 0 {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("should compare the first definition of the duplicated keys", func(t *testing.T) {
+		oldTranslations := map[string]TranslateStrings{
+			"en": {{Key: "hello", Default: "Hello"}, {Key: "hello", Default: "Hi"}},
+		}
+		newTranslations := map[string]TranslateStrings{
+			"en": {{Key: "hello", Default: "Hello"}, {Key: "hello", Default: "Hey"}},
+		}
+		if got := DiffCatalogs(oldTranslations, newTranslations); len(got) != 0 {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+
+		newTranslations["en"][0].Default = "Hello!"
+		got := DiffCatalogs(oldTranslations, newTranslations)
+		if len(got) != 1 || got[0].OldValue != "Hello" || got[0].NewValue != "Hello!" {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("should compare two instances", func(t *testing.T) {
+		older := NewI18n()
+		older.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+		newer := NewI18n()
+		newer.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hi"}})
+
+		expected := []CatalogChange{
+			{Type: ChangeModified, LanguageName: "en", Key: "hello", Field: "Default", OldValue: "Hello", NewValue: "Hi"},
+		}
+		if got := older.Diff(newer); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Unexpected result: %+v", got)
+		}
+	})
+}
@@ -14,40 +14,10 @@ var translationsFS embed.FS
 func InitializeI18n() {
 	i18n = goeasyi18n.NewI18n()
 
-	enTranslations, err := goeasyi18n.LoadFromYamlFS(
-		translationsFS,
-		"translations/en.yaml",
-	)
+	// Loads en.yaml, es.yaml, pt.yaml and fr.yaml, the language
+	// is inferred from the file names
+	err := i18n.LoadFS(translationsFS, "translations")
 	if err != nil {
 		panic(err)
 	}
-
-	esTranslations, err := goeasyi18n.LoadFromYamlFS(
-		translationsFS,
-		"translations/es.yaml",
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	ptTranslations, err := goeasyi18n.LoadFromYamlFS(
-		translationsFS,
-		"translations/pt.yaml",
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	frTranslations, err := goeasyi18n.LoadFromYamlFS(
-		translationsFS,
-		"translations/fr.yaml",
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	i18n.AddLanguage("en", enTranslations)
-	i18n.AddLanguage("es", esTranslations)
-	i18n.AddLanguage("pt", ptTranslations)
-	i18n.AddLanguage("fr", frTranslations)
 }
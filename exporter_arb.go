@@ -0,0 +1,118 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalArb serializes a list of TranslateString to an ARB file
+// (the format used by Flutter) in the same format that is used by
+// the ARB loaders, languageName is the "@@locale" of the file.
+//
+// The translations are sorted by key and converted to ICU messages,
+// only the templates that print fields (like {{.Name}}) can be
+// converted. ARB files can't have the same key more than once, so
+// the keys with multiple contexts return an error.
+func MarshalArb(
+	translateStrings TranslateStrings,
+	languageName string,
+) ([]byte, error) {
+	compact := new(bytes.Buffer)
+	compact.WriteString(`{"@@locale":`)
+	if err := writeJsonValue(compact, languageName); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, ts := range sortTranslateStrings(translateStrings) {
+		if seen[ts.Key] {
+			return nil, fmt.Errorf("goeasyi18n: the key '%s' is defined more than once, ARB can't have multiple contexts", ts.Key)
+		}
+		seen[ts.Key] = true
+
+		message, placeholders, err := translateStringToIcu(ts)
+		if err != nil {
+			return nil, fmt.Errorf("goeasyi18n: key '%s': %w", ts.Key, err)
+		}
+
+		compact.WriteString(",")
+		if err := writeJsonValue(compact, ts.Key); err != nil {
+			return nil, err
+		}
+		compact.WriteString(":")
+		if err := writeJsonValue(compact, message); err != nil {
+			return nil, err
+		}
+
+		metadata := arbMetadata{
+			Description: ts.Description,
+			Context:     ts.Context,
+			Screen:      ts.ScreenshotRef,
+			MaxLength:   ts.MaxLength,
+			Tags:        ts.Tags,
+			Status:      ts.Status,
+		}
+		if len(placeholders) > 0 {
+			metadata.Placeholders = map[string]json.RawMessage{}
+			for _, name := range placeholders {
+				metadata.Placeholders[name] = json.RawMessage(`{}`)
+			}
+			if _, isPlural := metadata.Placeholders[icuCountArgument]; isPlural {
+				metadata.Placeholders[icuCountArgument] = json.RawMessage(`{"type":"num"}`)
+			}
+		}
+		if reflect.DeepEqual(metadata, arbMetadata{}) {
+			continue
+		}
+
+		compact.WriteString(",")
+		if err := writeJsonValue(compact, "@"+ts.Key); err != nil {
+			return nil, err
+		}
+		compact.WriteString(":")
+		if err := writeJsonValue(compact, metadata); err != nil {
+			return nil, err
+		}
+	}
+	compact.WriteString("}")
+
+	b := new(bytes.Buffer)
+	if err := json.Indent(b, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	b.WriteString("\n")
+
+	return b.Bytes(), nil
+}
+
+// ExportLanguageArb serializes the translations of a loaded
+// language to an ARB file using MarshalArb.
+func (t *I18n) ExportLanguageArb(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalArb(lang, languageName)
+}
+
+// ExportArb serializes the translations of all the loaded languages
+// to ARB files using MarshalArb, it returns a map with the language
+// name as the key.
+func (t *I18n) ExportArb() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		arbBytes, err := t.ExportLanguageArb(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = arbBytes
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,109 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalArb(t *testing.T) {
+	t.Run("write the messages and their attributes", func(t *testing.T) {
+		arbBytes, err := MarshalArb(TranslateStrings{
+			{Key: "hello", Default: "Hello <b>{{.Name}}</b>", Description: "Greeting"},
+			{Key: "emails", One: "One email", Many: "{{.Qty}} emails"},
+			{Key: "title", Default: "Home"},
+		}, "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `{
+  "@@locale": "en",
+  "emails": "{count, plural, one {One email} other {{Qty} emails}}",
+  "@emails": {
+    "placeholders": {
+      "Qty": {},
+      "count": {
+        "type": "num"
+      }
+    }
+  },
+  "hello": "Hello <b>{Name}</b>",
+  "@hello": {
+    "description": "Greeting",
+    "placeholders": {
+      "Name": {}
+    }
+  },
+  "title": "Home"
+}
+`
+		if string(arbBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(arbBytes))
+		}
+	})
+
+	t.Run("exported arb can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "cats", Zero: "No cats", One: "One cat", Many: "{{.count}} cats", Male: "He has cats"},
+			{Key: "open", Context: "verb", Default: "Open '{{.what}}'", ScreenshotRef: "open.png"},
+			{Key: "pay", Default: "Pay", MaxLength: 10, Tags: []string{"checkout", "button"}, Status: StatusNeedsReview},
+		}
+		arbBytes, err := MarshalArb(original, "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromArbBytes(arbBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, original) {
+			t.Errorf("Unexpected result: %#v", loaded)
+		}
+	})
+
+	t.Run("handle translations that can't be exported", func(t *testing.T) {
+		_, err := MarshalArb(TranslateStrings{{Key: "open", Context: "verb"}, {Key: "open", Context: "status"}}, "en")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+
+		_, err = MarshalArb(TranslateStrings{{Key: "admin", Default: "{{if .Admin}}Admin{{end}}"}}, "en")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
+
+func TestExportArb(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		arbBytes, err := i18n.ExportLanguageArb("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "{\n  \"@@locale\": \"es\",\n  \"hello\": \"Hola\"\n}\n"
+		if string(arbBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(arbBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportArb()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageArb("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
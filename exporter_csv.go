@@ -0,0 +1,170 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalCsv serializes the translations of multiple languages to a
+// CSV file in the same format that is used by the CSV loaders, a
+// column per language and variant.
+//
+// The rows are sorted by key and only the columns that have any value
+// are written. The context and the metadata columns are shared by all
+// the languages of a row, they are taken from the first language (in
+// alphabetical order) that has them.
+func MarshalCsv(
+	translations map[string]TranslateStrings,
+) ([]byte, error) {
+	languageNames := make([]string, 0, len(translations))
+	for languageName := range translations {
+		languageNames = append(languageNames, languageName)
+	}
+	sort.Strings(languageNames)
+
+	rows := map[string]map[string]TranslateString{}
+	var rowIDs []string
+	var rowKeys []TranslateString
+	for _, languageName := range languageNames {
+		for _, ts := range translations[languageName] {
+			id := translationID(ts.Key, ts.Context)
+			if rows[id] == nil {
+				rows[id] = map[string]TranslateString{}
+				rowIDs = append(rowIDs, id)
+				rowKeys = append(rowKeys, TranslateString{Key: ts.Key, Context: ts.Context})
+			}
+			rows[id][languageName] = ts
+		}
+	}
+	sort.Sort(csvRows{ids: rowIDs, keys: rowKeys})
+
+	// Only the columns with values are written
+	extraColumns := []string{}
+	for _, extraField := range extraFieldNames {
+		for _, languageName := range languageNames {
+			if hasFieldValue(translations[languageName], extraField) {
+				extraColumns = append(extraColumns, extraField)
+				break
+			}
+		}
+	}
+	type csvColumn struct {
+		language string
+		variant  string
+	}
+	columns := []csvColumn{}
+	for _, languageName := range languageNames {
+		for _, variant := range variantNames {
+			if hasFieldValue(translations[languageName], variant) {
+				columns = append(columns, csvColumn{language: languageName, variant: variant})
+			}
+		}
+	}
+
+	header := append([]string{"Key"}, extraColumns...)
+	for _, column := range columns {
+		if column.variant == "Default" {
+			header = append(header, column.language)
+		} else {
+			header = append(header, column.language+"."+column.variant)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	writer := csv.NewWriter(b)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for i, id := range rowIDs {
+		record := []string{rowKeys[i].Key}
+		for _, extraField := range extraColumns {
+			var cell string
+			for _, languageName := range languageNames {
+				ts, exists := rows[id][languageName]
+				if !exists {
+					continue
+				}
+				if cell = csvFieldValue(ts, extraField); cell != "" {
+					break
+				}
+			}
+			record = append(record, cell)
+		}
+		for _, column := range columns {
+			ts := rows[id][column.language]
+			record = append(record, reflect.ValueOf(ts).FieldByName(column.variant).String())
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// csvRows sorts the rows by key and then by context
+type csvRows struct {
+	ids  []string
+	keys []TranslateString
+}
+
+func (r csvRows) Len() int { return len(r.ids) }
+
+func (r csvRows) Less(i, j int) bool {
+	if r.keys[i].Key != r.keys[j].Key {
+		return r.keys[i].Key < r.keys[j].Key
+	}
+	return r.keys[i].Context < r.keys[j].Context
+}
+
+func (r csvRows) Swap(i, j int) {
+	r.ids[i], r.ids[j] = r.ids[j], r.ids[i]
+	r.keys[i], r.keys[j] = r.keys[j], r.keys[i]
+}
+
+// hasFieldValue checks if any translation has a value in the field
+func hasFieldValue(translateStrings TranslateStrings, name string) bool {
+	for _, ts := range translateStrings {
+		if !reflect.ValueOf(ts).FieldByName(name).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// csvFieldValue formats a field like it is loaded from the CSV cells
+func csvFieldValue(ts TranslateString, name string) string {
+	field := reflect.ValueOf(ts).FieldByName(name)
+	if field.IsZero() {
+		return ""
+	}
+	switch field.Kind() {
+	case reflect.Int:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Slice:
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = field.Index(i).String()
+		}
+		return strings.Join(items, ", ")
+	}
+	return field.String()
+}
+
+// ExportCsv serializes the translations of all the loaded
+// languages to a single CSV file using MarshalCsv.
+func (t *I18n) ExportCsv() ([]byte, error) {
+	return MarshalCsv(t.languages)
+}
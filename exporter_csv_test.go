@@ -0,0 +1,70 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalCsv(t *testing.T) {
+	t.Run("write a column per language and variant", func(t *testing.T) {
+		csvBytes, err := MarshalCsv(map[string]TranslateStrings{
+			"es": {
+				{Key: "hello", Default: "Hola, {{.Name}}"},
+				{Key: "emails", One: "Un correo", Many: "Muchos correos"},
+			},
+			"en": {
+				{Key: "hello", Default: "Hello, {{.Name}}", Tags: []string{"home", "greeting"}},
+				{Key: "emails", One: "One email", Many: "Many emails", MaxLength: 20},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `Key,MaxLength,Tags,en,en.One,en.Many,es,es.One,es.Many
+emails,20,,,One email,Many emails,,Un correo,Muchos correos
+hello,,"home, greeting","Hello, {{.Name}}",,,"Hola, {{.Name}}",,
+`
+		if string(csvBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(csvBytes))
+		}
+	})
+
+	t.Run("exported csv can be loaded again", func(t *testing.T) {
+		original := map[string]TranslateStrings{
+			"en": {
+				{Key: "open", Context: "status", Default: "Opened", Description: "Status"},
+				{Key: "open", Context: "verb", Default: "Open", Description: "Button"},
+			},
+			"es": {
+				{Key: "open", Context: "status", Default: "Abierto", Description: "Status"},
+				{Key: "open", Context: "verb", Default: "Abrir", Description: "Button"},
+			},
+		}
+		csvBytes, err := MarshalCsv(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromCsvBytes(csvBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, original) {
+			t.Errorf("Unexpected result: %#v", loaded)
+		}
+	})
+}
+
+func TestExportCsv(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	csvBytes, err := i18n.ExportCsv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(csvBytes) != "Key,en,es\nhello,Hello,Hola\n" {
+		t.Errorf("Unexpected result: %s", string(csvBytes))
+	}
+}
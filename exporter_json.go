@@ -0,0 +1,81 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// MarshalJson serializes a list of TranslateString to JSON in the
+// same format that is used by the JSON loaders.
+//
+// The translations are sorted by key and the empty
+// fields are omitted, so the output is stable.
+func MarshalJson(
+	translateStrings TranslateStrings,
+) ([]byte, error) {
+	sorted := sortTranslateStrings(translateStrings)
+
+	// HTML is not escaped because the translations
+	// are templates that can contain HTML
+	b := new(bytes.Buffer)
+	encoder := json.NewEncoder(b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// ExportLanguageJson serializes the translations of a
+// loaded language to JSON using MarshalJson.
+func (t *I18n) ExportLanguageJson(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalJson(lang)
+}
+
+// ExportJson serializes the translations of all the loaded
+// languages to JSON using MarshalJson, it returns a map
+// with the language name as the key.
+func (t *I18n) ExportJson() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		jsonBytes, err := t.ExportLanguageJson(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = jsonBytes
+	}
+
+	return exported, nil
+}
+
+// sortTranslateStrings returns a copy of the list
+// of TranslateString sorted by key and context
+func sortTranslateStrings(
+	translateStrings TranslateStrings,
+) TranslateStrings {
+	sorted := make(TranslateStrings, len(translateStrings))
+	copy(sorted, translateStrings)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Key != sorted[j].Key {
+			return sorted[i].Key < sorted[j].Key
+		}
+		return sorted[i].Context < sorted[j].Context
+	})
+
+	return sorted
+}
@@ -0,0 +1,95 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalJson(t *testing.T) {
+	t.Run("sort by key and omit empty variants", func(t *testing.T) {
+		jsonBytes, err := MarshalJson(TranslateStrings{
+			{Key: "world", Default: "World"},
+			{Key: "hello", One: "<b>One</b>", Many: "Many"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `[
+  {
+    "Key": "hello",
+    "One": "<b>One</b>",
+    "Many": "Many"
+  },
+  {
+    "Key": "world",
+    "Default": "World"
+  }
+]
+`
+		if string(jsonBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(jsonBytes))
+		}
+	})
+
+	t.Run("marshal empty list", func(t *testing.T) {
+		jsonBytes, err := MarshalJson(nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(jsonBytes) != "[]\n" {
+			t.Errorf("Unexpected result: %s", string(jsonBytes))
+		}
+	})
+
+	t.Run("exported json can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "hello", Default: "Hello {{.Name}}", Female: "Hello ma'am", Description: "Greeting", MaxLength: 20, Tags: []string{"home"}, ScreenshotRef: "home.png"},
+		}
+		jsonBytes, err := MarshalJson(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromJsonBytes(jsonBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(loaded) != 1 || !reflect.DeepEqual(loaded[0], original[0]) {
+			t.Errorf("Unexpected result: %v", loaded)
+		}
+	})
+}
+
+func TestExportJson(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		jsonBytes, err := i18n.ExportLanguageJson("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, _ := LoadFromJsonBytes(jsonBytes)
+		if len(loaded) != 1 || loaded[0].Default != "Hola" {
+			t.Errorf("Unexpected result: %s", string(jsonBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportJson()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageJson("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
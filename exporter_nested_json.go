@@ -0,0 +1,198 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MarshalNestedJson serializes a list of TranslateString to
+// nested JSON, the format that is used by LoadFromNestedJsonBytes.
+//
+// The keys are split by their dots into nested objects and the
+// translations that only have the Default field are written as
+// texts. The nested objects can't have the same key more than
+// once, so the keys with multiple contexts return an error.
+func MarshalNestedJson(
+	translateStrings TranslateStrings,
+) ([]byte, error) {
+	root := &nestedJsonNode{}
+
+	for _, ts := range sortTranslateStrings(translateStrings) {
+		node := root
+		for _, name := range strings.Split(ts.Key, ".") {
+			if node.translation != nil {
+				return nil, fmt.Errorf("goeasyi18n: the key '%s' is inside the translation '%s'", ts.Key, node.translation.Key)
+			}
+			if node.children == nil {
+				node.children = map[string]*nestedJsonNode{}
+			}
+			child, exists := node.children[name]
+			if !exists {
+				child = &nestedJsonNode{}
+				node.children[name] = child
+			}
+			node = child
+		}
+
+		if node.translation != nil {
+			return nil, fmt.Errorf("goeasyi18n: the key '%s' is defined more than once, nested JSON can't have multiple contexts", ts.Key)
+		}
+		if len(node.children) > 0 {
+			return nil, fmt.Errorf("goeasyi18n: the key '%s' is also a group of keys", ts.Key)
+		}
+		translation := ts
+		node.translation = &translation
+	}
+
+	compact := new(bytes.Buffer)
+	if err := root.write(compact, ""); err != nil {
+		return nil, err
+	}
+
+	b := new(bytes.Buffer)
+	if err := json.Indent(b, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	b.WriteString("\n")
+
+	return b.Bytes(), nil
+}
+
+// nestedJsonNode is a translation or a group of keys
+type nestedJsonNode struct {
+	translation *TranslateString
+	children    map[string]*nestedJsonNode
+}
+
+// write writes the node as compact JSON, the
+// names of the objects are sorted
+func (n *nestedJsonNode) write(b *bytes.Buffer, key string) error {
+	if n.translation != nil {
+		return writeNestedJsonTranslation(b, *n.translation)
+	}
+
+	names := make([]string, 0, len(n.children))
+	allFieldNames := true
+	for name := range n.children {
+		names = append(names, name)
+		if !isVariantName(name) && !isExtraFieldName(name) {
+			allFieldNames = false
+		}
+	}
+	sort.Strings(names)
+
+	// A group like {"One": ...} would be loaded as a translation
+	if key != "" && allFieldNames {
+		return fmt.Errorf("goeasyi18n: the keys inside '%s' are named like TranslateString fields, they can't be nested", key)
+	}
+
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		if err := writeJsonValue(b, name); err != nil {
+			return err
+		}
+		b.WriteString(":")
+
+		childKey := name
+		if key != "" {
+			childKey = key + "." + name
+		}
+		if err := n.children[name].write(b, childKey); err != nil {
+			return err
+		}
+	}
+	b.WriteString("}")
+
+	return nil
+}
+
+// writeNestedJsonTranslation writes the translation as a text if it only
+// has the Default field (or no fields), or as an object with its fields
+// in order
+func writeNestedJsonTranslation(b *bytes.Buffer, ts TranslateString) error {
+	value := reflect.ValueOf(ts)
+
+	onlyDefault := true
+	for i := 0; i < value.NumField() && onlyDefault; i++ {
+		name := value.Type().Field(i).Name
+		if name != "Key" && name != "Default" && !value.Field(i).IsZero() {
+			onlyDefault = false
+		}
+	}
+	if onlyDefault {
+		return writeJsonValue(b, ts.Default)
+	}
+
+	b.WriteString("{")
+	written := 0
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Name
+		if name == "Key" || value.Field(i).IsZero() {
+			continue
+		}
+		if written > 0 {
+			b.WriteString(",")
+		}
+		written++
+		if err := writeJsonValue(b, name); err != nil {
+			return err
+		}
+		b.WriteString(":")
+		if err := writeJsonValue(b, value.Field(i).Interface()); err != nil {
+			return err
+		}
+	}
+	b.WriteString("}")
+
+	return nil
+}
+
+// writeJsonValue writes a value as compact JSON without escaping
+// the HTML, the translations are templates that can contain HTML
+func writeJsonValue(b *bytes.Buffer, value any) error {
+	encoded := new(bytes.Buffer)
+	encoder := json.NewEncoder(encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	b.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+	return nil
+}
+
+// ExportLanguageNestedJson serializes the translations of a
+// loaded language to nested JSON using MarshalNestedJson.
+func (t *I18n) ExportLanguageNestedJson(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalNestedJson(lang)
+}
+
+// ExportNestedJson serializes the translations of all the loaded
+// languages to nested JSON using MarshalNestedJson, it returns a
+// map with the language name as the key.
+func (t *I18n) ExportNestedJson() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		jsonBytes, err := t.ExportLanguageNestedJson(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = jsonBytes
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,100 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalNestedJson(t *testing.T) {
+	t.Run("nest the keys and sort them", func(t *testing.T) {
+		jsonBytes, err := MarshalNestedJson(TranslateStrings{
+			{Key: "hello", Default: "Hello <b>{{.Name}}</b>"},
+			{Key: "auth.title", Default: "Sign in"},
+			{Key: "auth.emails", One: "One email", Many: "Many emails"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `{
+  "auth": {
+    "emails": {
+      "One": "One email",
+      "Many": "Many emails"
+    },
+    "title": "Sign in"
+  },
+  "hello": "Hello <b>{{.Name}}</b>"
+}
+`
+		if string(jsonBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(jsonBytes))
+		}
+	})
+
+	t.Run("exported nested json can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "auth.title", Default: "Sign in", Description: "Title", Tags: []string{"auth"}},
+			{Key: "empty"},
+			{Key: "open", Context: "verb", Default: "Open"},
+		}
+		jsonBytes, err := MarshalNestedJson(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromNestedJsonBytes(jsonBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, original) {
+			t.Errorf("Unexpected result: %v", loaded)
+		}
+	})
+
+	t.Run("handle keys that can't be nested", func(t *testing.T) {
+		cases := []TranslateStrings{
+			{{Key: "auth", Default: "Auth"}, {Key: "auth.title", Default: "Sign in"}},
+			{{Key: "open", Context: "verb"}, {Key: "open", Context: "status"}},
+			{{Key: "emails.One", Default: "One email"}},
+		}
+		for _, translateStrings := range cases {
+			if _, err := MarshalNestedJson(translateStrings); err == nil {
+				t.Errorf("Expected error for %v, got nil", translateStrings)
+			}
+		}
+	})
+}
+
+func TestExportNestedJson(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "home.title", Default: "Home"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "home.title", Default: "Inicio"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		jsonBytes, err := i18n.ExportLanguageNestedJson("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "{\n  \"home\": {\n    \"title\": \"Inicio\"\n  }\n}\n"
+		if string(jsonBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(jsonBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportNestedJson()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageNestedJson("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
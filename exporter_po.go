@@ -0,0 +1,178 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MarshalPo serializes a list of TranslateString to a gettext PO
+// file in the same format that is used by the PO loaders.
+//
+// The translations are sorted by key. The plural variants are written
+// as msgstr[N] forms with the same number of forms for all the entries
+// (the least that holds all the variants, see poPluralVariants). PO has
+// no genders, so the translations with gender variants or with both a
+// Default and plural variants return an error. Only the Description and
+// the fuzzy status are kept from the metadata.
+func MarshalPo(
+	translateStrings TranslateStrings,
+	languageName string,
+) ([]byte, error) {
+	sorted := sortTranslateStrings(translateStrings)
+
+	// The number of plural forms is the same for all the entries
+	pluralVariants := poPluralVariants[0]
+	for _, ts := range sorted {
+		for _, variant := range translationPluralVariants(ts) {
+			for !containsString(pluralVariants, variant) {
+				pluralVariants = poPluralVariants[len(pluralVariants)]
+			}
+		}
+	}
+
+	b := new(bytes.Buffer)
+	b.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	fmt.Fprintf(b, "\"Language: %s\\n\"\n", escapePoString(languageName))
+	b.WriteString("\"MIME-Version: 1.0\\n\"\n")
+	b.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	b.WriteString("\"Content-Transfer-Encoding: 8bit\\n\"\n")
+	switch len(pluralVariants) {
+	case 1:
+		b.WriteString("\"Plural-Forms: nplurals=1; plural=0;\\n\"\n")
+	case 2:
+		// The same rule as DefaultPluralizationFunc
+		b.WriteString("\"Plural-Forms: nplurals=2; plural=(n != 1);\\n\"\n")
+	}
+
+	for _, ts := range sorted {
+		if hasGenderVariants(ts) {
+			return nil, fmt.Errorf("goeasyi18n: the key %s has gender variants, they can't be exported to PO", describeKey(ts.Key, ts.Context))
+		}
+		plurals := translationPluralVariants(ts)
+		if ts.Default != "" && len(plurals) > 0 {
+			return nil, fmt.Errorf("goeasyi18n: the key %s has a Default and plural variants, PO can only have one of them", describeKey(ts.Key, ts.Context))
+		}
+
+		b.WriteString("\n")
+		if ts.Description != "" {
+			for _, line := range strings.Split(ts.Description, "\n") {
+				fmt.Fprintf(b, "#. %s\n", line)
+			}
+		}
+		if ts.Status == StatusFuzzy {
+			b.WriteString("#, fuzzy\n")
+		}
+		if ts.Context != "" {
+			writePoString(b, "msgctxt", ts.Context)
+		}
+		writePoString(b, "msgid", ts.Key)
+
+		if len(plurals) == 0 {
+			writePoString(b, "msgstr", ts.Default)
+			continue
+		}
+		writePoString(b, "msgid_plural", ts.Key)
+		value := reflect.ValueOf(ts)
+		for i, variant := range pluralVariants {
+			writePoString(b, fmt.Sprintf("msgstr[%d]", i), value.FieldByName(variant).String())
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// translationPluralVariants returns the plural variants
+// without gender that the translation has
+func translationPluralVariants(ts TranslateString) []string {
+	value := reflect.ValueOf(ts)
+	var variants []string
+	for _, variant := range []string{"Zero", "One", "Two", "Few", "Many"} {
+		if value.FieldByName(variant).String() != "" {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+// hasGenderVariants checks if the translation has any
+// gender variant (with or without pluralization)
+func hasGenderVariants(ts TranslateString) bool {
+	value := reflect.ValueOf(ts)
+	for _, variant := range variantNames {
+		isGender := strings.HasSuffix(variant, "Male") || strings.HasSuffix(variant, "Female") || strings.HasSuffix(variant, "NonBinary")
+		if isGender && value.FieldByName(variant).String() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// writePoString writes a keyword and its string, the strings with
+// line breaks are split in multiple lines like gettext does it
+func writePoString(b *bytes.Buffer, keyword string, s string) {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		fmt.Fprintf(b, "%s \"%s\"\n", keyword, escapePoString(s))
+		return
+	}
+
+	fmt.Fprintf(b, "%s \"\"\n", keyword)
+	for _, line := range lines {
+		fmt.Fprintf(b, "\"%s\"\n", escapePoString(line))
+	}
+}
+
+func escapePoString(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\t", `\t`,
+		"\r", `\r`,
+	)
+	return replacer.Replace(s)
+}
+
+// ExportLanguagePo serializes the translations of a loaded
+// language to a gettext PO file using MarshalPo.
+func (t *I18n) ExportLanguagePo(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalPo(lang, languageName)
+}
+
+// ExportPo serializes the translations of all the loaded languages
+// to gettext PO files using MarshalPo, it returns a map with the
+// language name as the key.
+func (t *I18n) ExportPo() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		poBytes, err := t.ExportLanguagePo(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = poBytes
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,107 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalPo(t *testing.T) {
+	t.Run("write the header and the entries", func(t *testing.T) {
+		poBytes, err := MarshalPo(TranslateStrings{
+			{Key: "hello", Default: "Hola \"{{.Name}}\"", Description: "Greeting"},
+			{Key: "emails", One: "Un correo", Many: "Muchos\ncorreos", Status: StatusFuzzy},
+		}, "es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `msgid ""
+msgstr ""
+"Language: es\n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+#, fuzzy
+msgid "emails"
+msgid_plural "emails"
+msgstr[0] "Un correo"
+msgstr[1] ""
+"Muchos\n"
+"correos"
+
+#. Greeting
+msgid "hello"
+msgstr "Hola \"{{.Name}}\""
+`
+		if string(poBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(poBytes))
+		}
+	})
+
+	t.Run("exported po can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "apples", Zero: "No apples", One: "One apple", Many: "{{.Qty}} apples"},
+			{Key: "open", Context: "verb", Default: "Open\t\\"},
+			{Key: "pears", One: "One pear", Many: "Pears"},
+		}
+		poBytes, err := MarshalPo(original, "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromPoBytes(poBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, original) {
+			t.Errorf("Unexpected result: %#v", loaded)
+		}
+	})
+
+	t.Run("handle variants that can't be exported", func(t *testing.T) {
+		_, err := MarshalPo(TranslateStrings{{Key: "hello", Male: "Hello sir"}}, "en")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+
+		_, err = MarshalPo(TranslateStrings{{Key: "hello", Default: "Hello", One: "One"}}, "en")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
+
+func TestExportPo(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		poBytes, err := i18n.ExportLanguagePo("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, _ := LoadFromPoBytes(poBytes)
+		if len(loaded) != 1 || loaded[0].Default != "Hola" {
+			t.Errorf("Unexpected result: %s", string(poBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportPo()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguagePo("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalProperties serializes a list of TranslateString to the
+// Java .properties format that is used by the .properties loaders.
+//
+// The translations are sorted by key and the empty fields are
+// omitted, so the output is stable. The Default field is written
+// as the property of the key and the other fields as suffixes.
+func MarshalProperties(
+	translateStrings TranslateStrings,
+) ([]byte, error) {
+	sorted := sortTranslateStrings(translateStrings)
+
+	// A context property only starts a new translation if the previous
+	// one with the same key has a context, so the translation without
+	// context goes after the others
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Key != sorted[j].Key {
+			return sorted[i].Key < sorted[j].Key
+		}
+		if sorted[i].Context == "" || sorted[j].Context == "" {
+			return sorted[i].Context != "" && sorted[j].Context == ""
+		}
+		return sorted[i].Context < sorted[j].Context
+	})
+
+	b := new(bytes.Buffer)
+	for i, ts := range sorted {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeProperties(b, ts)
+	}
+
+	return b.Bytes(), nil
+}
+
+// writeProperties writes the properties of a translation, the
+// context is written first because a repeated key with another
+// context starts a new translation when it is loaded
+func writeProperties(b *bytes.Buffer, ts TranslateString) {
+	name := escapePropertyName(ts.Key)
+	if ts.Context != "" {
+		fmt.Fprintf(b, "%s.Context = %s\n", name, escapePropertyValue(ts.Context))
+	}
+
+	value := reflect.ValueOf(ts)
+	for i := 0; i < value.NumField(); i++ {
+		fieldName := value.Type().Field(i).Name
+		field := value.Field(i)
+		if fieldName == "Key" || fieldName == "Context" || field.IsZero() {
+			continue
+		}
+
+		propertyName := name + "." + fieldName
+		if fieldName == "Default" {
+			propertyName = name
+		}
+
+		var text string
+		switch field.Kind() {
+		case reflect.String:
+			text = field.String()
+		case reflect.Int:
+			text = strconv.FormatInt(field.Int(), 10)
+		case reflect.Bool:
+			text = strconv.FormatBool(field.Bool())
+		case reflect.Slice:
+			items := make([]string, field.Len())
+			for j := range items {
+				items[j] = field.Index(j).String()
+			}
+			text = strings.Join(items, ", ")
+		}
+		fmt.Fprintf(b, "%s = %s\n", propertyName, escapePropertyValue(text))
+	}
+}
+
+// escapePropertyName escapes the characters that
+// would end the name of a property
+func escapePropertyName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		switch r {
+		case ' ', '=', ':', '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '#', '!':
+			if i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(escapePropertyControl(r))
+		}
+	}
+	return sb.String()
+}
+
+// escapePropertyValue escapes the backslashes, the line breaks
+// and the leading spaces of the value of a property
+func escapePropertyValue(value string) string {
+	var sb strings.Builder
+	for i, r := range value {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == ' ' && i == 0:
+			sb.WriteString(`\ `)
+		default:
+			sb.WriteString(escapePropertyControl(r))
+		}
+	}
+	return sb.String()
+}
+
+func escapePropertyControl(r rune) string {
+	switch r {
+	case '\n':
+		return `\n`
+	case '\t':
+		return `\t`
+	case '\r':
+		return `\r`
+	case '\f':
+		return `\f`
+	}
+	if r < 0x20 || r == 0x7f {
+		code := strconv.FormatInt(int64(r), 16)
+		return `\u` + strings.Repeat("0", 4-len(code)) + code
+	}
+	return string(r)
+}
+
+// ExportLanguageProperties serializes the translations of a loaded
+// language to the .properties format using MarshalProperties.
+func (t *I18n) ExportLanguageProperties(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalProperties(lang)
+}
+
+// ExportProperties serializes the translations of all the loaded
+// languages to the .properties format using MarshalProperties, it
+// returns a map with the language name as the key.
+func (t *I18n) ExportProperties() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		propertiesBytes, err := t.ExportLanguageProperties(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = propertiesBytes
+	}
+
+	return exported, nil
+}
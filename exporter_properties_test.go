@@ -0,0 +1,101 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalProperties(t *testing.T) {
+	t.Run("sort by key and omit empty fields", func(t *testing.T) {
+		propertiesBytes, err := MarshalProperties(TranslateStrings{
+			{Key: "world", Default: "World"},
+			{Key: "hello_emails", One: "One email", Many: "{{.Qty}} emails", Tags: []string{"home", "mail"}},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `hello_emails.One = One email
+hello_emails.Many = {{.Qty}} emails
+hello_emails.Tags = home, mail
+
+world = World
+`
+		if string(propertiesBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(propertiesBytes))
+		}
+	})
+
+	t.Run("write the context before the texts", func(t *testing.T) {
+		propertiesBytes, err := MarshalProperties(TranslateStrings{
+			{Key: "open", Context: "verb", Default: "Open"},
+			{Key: "open", Context: "status", Default: "Opened"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `open.Context = status
+open = Opened
+
+open.Context = verb
+open = Open
+`
+		if string(propertiesBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(propertiesBytes))
+		}
+	})
+
+	t.Run("exported properties can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "#key with: spaces=", Default: " Line 1\nLine 2 \\ {{.Name}}", MaxLength: 30, Deprecated: true},
+			{Key: "open", Default: "Open"},
+			{Key: "open", Context: "verb", Default: "Open"},
+			{Key: "open", Context: "status", Default: "Opened"},
+		}
+		propertiesBytes, err := MarshalProperties(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromPropertiesBytes(propertiesBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n%s", err, propertiesBytes)
+		}
+		if !reflect.DeepEqual(sortTranslateStrings(loaded), sortTranslateStrings(original)) {
+			t.Errorf("Unexpected result: %v", loaded)
+		}
+	})
+}
+
+func TestExportProperties(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		propertiesBytes, err := i18n.ExportLanguageProperties("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(propertiesBytes) != "hello = Hola\n" {
+			t.Errorf("Unexpected result: %s", string(propertiesBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportProperties()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageProperties("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MarshalToml serializes a list of TranslateString to TOML in the
+// same format that is used by the TOML loaders, a table per key.
+//
+// The translations are sorted by key and the empty fields are
+// omitted, so the output is stable. The keys that are defined
+// more than once (with different contexts) use arrays of tables.
+func MarshalToml(
+	translateStrings TranslateStrings,
+) ([]byte, error) {
+	sorted := sortTranslateStrings(translateStrings)
+
+	keyCount := map[string]int{}
+	for _, ts := range sorted {
+		keyCount[ts.Key]++
+	}
+
+	b := new(bytes.Buffer)
+	for i, ts := range sorted {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeTomlTable(b, ts, keyCount[ts.Key] > 1 || ts.Context != "")
+	}
+
+	return b.Bytes(), nil
+}
+
+// writeTomlTable writes a translation as a TOML table
+// (or as an element of an array of tables)
+func writeTomlTable(b *bytes.Buffer, ts TranslateString, isArray bool) {
+	if isArray {
+		fmt.Fprintf(b, "[[%s]]\n", tomlTableName(ts.Key))
+	} else {
+		fmt.Fprintf(b, "[%s]\n", tomlTableName(ts.Key))
+	}
+
+	value := reflect.ValueOf(ts)
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Name
+		field := value.Field(i)
+		if name == "Key" || field.IsZero() {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			fmt.Fprintf(b, "%s = %s\n", name, tomlString(field.String()))
+		case reflect.Int:
+			fmt.Fprintf(b, "%s = %d\n", name, field.Int())
+		case reflect.Bool:
+			fmt.Fprintf(b, "%s = %t\n", name, field.Bool())
+		case reflect.Slice:
+			items := make([]string, field.Len())
+			for j := range items {
+				items[j] = tomlString(field.Index(j).String())
+			}
+			fmt.Fprintf(b, "%s = [%s]\n", name, strings.Join(items, ", "))
+		}
+	}
+}
+
+// tomlTableName writes the key as a dotted TOML key, the parts
+// that aren't bare keys are quoted (the loader joins them again)
+func tomlTableName(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		isBare := part != ""
+		for j := 0; j < len(part); j++ {
+			if !isTomlBareKeyChar(part[j]) {
+				isBare = false
+				break
+			}
+		}
+		if !isBare {
+			parts[i] = tomlString(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlString quotes a TOML basic string
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(`\u`)
+				code := strconv.FormatInt(int64(r), 16)
+				sb.WriteString(strings.Repeat("0", 4-len(code)) + code)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// ExportLanguageToml serializes the translations of a
+// loaded language to TOML using MarshalToml.
+func (t *I18n) ExportLanguageToml(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalToml(lang)
+}
+
+// ExportToml serializes the translations of all the loaded
+// languages to TOML using MarshalToml, it returns a map
+// with the language name as the key.
+func (t *I18n) ExportToml() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		tomlBytes, err := t.ExportLanguageToml(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = tomlBytes
+	}
+
+	return exported, nil
+}
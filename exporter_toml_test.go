@@ -0,0 +1,103 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalToml(t *testing.T) {
+	t.Run("sort by key and omit empty fields", func(t *testing.T) {
+		tomlBytes, err := MarshalToml(TranslateStrings{
+			{Key: "world", Default: "World"},
+			{Key: "hello_emails", One: "One email", Many: "{{.Qty}} emails", MaxLength: 20},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `[hello_emails]
+One = "One email"
+Many = "{{.Qty}} emails"
+MaxLength = 20
+
+[world]
+Default = "World"
+`
+		if string(tomlBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(tomlBytes))
+		}
+	})
+
+	t.Run("use arrays of tables for the keys with a context", func(t *testing.T) {
+		tomlBytes, err := MarshalToml(TranslateStrings{
+			{Key: "open", Context: "verb", Default: "Open"},
+			{Key: "open", Context: "status", Default: "Opened"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `[[open]]
+Context = "status"
+Default = "Opened"
+
+[[open]]
+Context = "verb"
+Default = "Open"
+`
+		if string(tomlBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(tomlBytes))
+		}
+	})
+
+	t.Run("exported toml can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "home.title", Default: "Say \"hi\"\n\tto \\ {{.Name}}", Description: "Title", Tags: []string{"home", "title"}, Deprecated: true},
+			{Key: "with space", Context: "menu", Default: "Space"},
+		}
+		tomlBytes, err := MarshalToml(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromTomlBytes(tomlBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n%s", err, tomlBytes)
+		}
+		if !reflect.DeepEqual(loaded, original) {
+			t.Errorf("Unexpected result: %v", loaded)
+		}
+	})
+}
+
+func TestExportToml(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		tomlBytes, err := i18n.ExportLanguageToml("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(tomlBytes) != "[hello]\nDefault = \"Hola\"\n" {
+			t.Errorf("Unexpected result: %s", string(tomlBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportToml()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageToml("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MarshalXliff serializes the translations of a source and a target
+// language to an XLIFF 1.2 file in the same format that is used by
+// the XLIFF loaders. Without a target language (an empty
+// targetLanguageName) only the source texts are written, so the file
+// can be sent to the translators.
+//
+// Every variant of a translation is a trans-unit, sorted by key. The
+// keys that only exist in the target language are written with an
+// empty source. The metadata is kept as described in
+// LoadFromXliffBytes, so the exported file can be loaded again.
+func MarshalXliff(
+	translations map[string]TranslateStrings,
+	sourceLanguageName string,
+	targetLanguageName string,
+) ([]byte, error) {
+	source, exists := translations[sourceLanguageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", sourceLanguageName)
+	}
+	var target TranslateStrings
+	if targetLanguageName != "" {
+		target, exists = translations[targetLanguageName]
+		if !exists {
+			return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", targetLanguageName)
+		}
+	}
+
+	sourceByID := map[string]TranslateString{}
+	targetByID := map[string]TranslateString{}
+	var ids []string
+	for _, ts := range source {
+		id := translationID(ts.Key, ts.Context)
+		if _, exists := sourceByID[id]; !exists {
+			ids = append(ids, id)
+		}
+		sourceByID[id] = ts
+	}
+	for _, ts := range target {
+		id := translationID(ts.Key, ts.Context)
+		if _, exists := sourceByID[id]; !exists {
+			if _, exists := targetByID[id]; !exists {
+				ids = append(ids, id)
+			}
+		}
+		targetByID[id] = ts
+	}
+
+	// Sorted by key and then by context
+	entries := make(TranslateStrings, 0, len(ids))
+	for _, id := range ids {
+		ts, exists := sourceByID[id]
+		if !exists {
+			ts = TranslateString{Key: targetByID[id].Key, Context: targetByID[id].Context}
+		}
+		entries = append(entries, ts)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Key != entries[j].Key {
+			return entries[i].Key < entries[j].Key
+		}
+		return entries[i].Context < entries[j].Context
+	})
+
+	file := xliffFile{
+		Original:       "messages",
+		SourceLanguage: sourceLanguageName,
+		TargetLanguage: targetLanguageName,
+		Datatype:       "plaintext",
+		Units:          []xliffUnit{},
+	}
+
+	for _, sourceTs := range entries {
+		id := translationID(sourceTs.Key, sourceTs.Context)
+		targetTs, hasTarget := targetByID[id]
+
+		metadata := sourceTs
+		if metadata.Description == "" && metadata.MaxLength == 0 && len(metadata.Tags) == 0 {
+			metadata = targetTs
+		}
+
+		sourceValue := reflect.ValueOf(sourceTs)
+		targetValue := reflect.ValueOf(targetTs)
+		var variants []string
+		for _, variant := range variantNames {
+			if sourceValue.FieldByName(variant).String() != "" || targetValue.FieldByName(variant).String() != "" {
+				variants = append(variants, variant)
+			}
+		}
+		// A translation without texts has an empty Default unit
+		if len(variants) == 0 {
+			variants = []string{"Default"}
+		}
+
+		for _, variant := range variants {
+			sourceText := sourceValue.FieldByName(variant).String()
+			targetText := targetValue.FieldByName(variant).String()
+
+			unitID := sourceTs.Key
+			if variant != "Default" {
+				unitID += "." + variant
+			}
+			if sourceTs.Context != "" {
+				unitID = sourceTs.Context + "/" + unitID
+			}
+
+			unit := xliffUnit{
+				ID:      unitID,
+				Resname: sourceTs.Key,
+				Source:  sourceText,
+			}
+			if metadata.MaxLength > 0 {
+				unit.MaxWidth = metadata.MaxLength
+				unit.SizeUnit = "char"
+			}
+			if metadata.Description != "" {
+				unit.Notes = append(unit.Notes, xliffNote{Text: metadata.Description})
+			}
+			if len(metadata.Tags) > 0 {
+				unit.Notes = append(unit.Notes, xliffNote{From: xliffTagsNote, Text: strings.Join(metadata.Tags, ",")})
+			}
+			if sourceTs.Status != "" {
+				unit.Notes = append(unit.Notes, xliffNote{From: xliffStatusNote, Text: string(sourceTs.Status)})
+			}
+			if sourceTs.Context != "" {
+				unit.ContextGroups = []xliffContextGroup{{
+					Purpose:  "information",
+					Contexts: []xliffContext{{Type: xliffContextType, Text: sourceTs.Context}},
+				}}
+			}
+			if hasTarget {
+				unit.Target = &xliffTarget{Text: targetText, State: "translated"}
+				switch targetTs.Status {
+				case StatusApproved:
+					unit.Target.State = "final"
+				case StatusNeedsReview:
+					unit.Target.State = "needs-review-translation"
+				case StatusFuzzy:
+					unit.Target.State = "needs-review-translation"
+					unit.Target.StateQualifier = "fuzzy-match"
+				}
+			}
+
+			file.Units = append(file.Units, unit)
+		}
+	}
+
+	document := xliffDocument{
+		Xmlns:   "urn:oasis:names:tc:xliff:document:1.2",
+		Version: "1.2",
+		Files:   []xliffFile{file},
+	}
+
+	b := new(bytes.Buffer)
+	b.WriteString(xml.Header)
+	encoder := xml.NewEncoder(b)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	b.WriteString("\n")
+
+	return b.Bytes(), nil
+}
+
+// ExportXliff serializes the translations of two loaded languages
+// to an XLIFF 1.2 file using MarshalXliff, without a target language
+// only the source texts are written.
+func (t *I18n) ExportXliff(
+	sourceLanguageName string,
+	targetLanguageName string,
+) ([]byte, error) {
+	return MarshalXliff(t.languages, sourceLanguageName, targetLanguageName)
+}
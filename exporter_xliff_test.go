@@ -0,0 +1,148 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalXliff(t *testing.T) {
+	translations := map[string]TranslateStrings{
+		"en": {
+			{Key: "hello", Default: "Hello <b>{{.Name}}</b>", Description: "Greeting", MaxLength: 30},
+			{Key: "emails", One: "One email", Many: "Many emails"},
+			{Key: "open", Context: "verb", Default: "Open"},
+		},
+		"es": {
+			{Key: "hello", Default: "Hola <b>{{.Name}}</b>", Status: StatusApproved},
+			{Key: "emails", One: "Un correo", Many: "Muchos correos", Status: StatusFuzzy},
+			{Key: "spanish_only", Default: "Solo español"},
+		},
+	}
+
+	t.Run("write the source texts", func(t *testing.T) {
+		xliffBytes, err := MarshalXliff(translations, "en", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="messages" source-language="en" datatype="plaintext">
+    <body>
+      <trans-unit id="emails.One" resname="emails">
+        <source>One email</source>
+      </trans-unit>
+      <trans-unit id="emails.Many" resname="emails">
+        <source>Many emails</source>
+      </trans-unit>
+      <trans-unit id="hello" resname="hello" maxwidth="30" size-unit="char">
+        <source>Hello &lt;b&gt;{{.Name}}&lt;/b&gt;</source>
+        <note>Greeting</note>
+      </trans-unit>
+      <trans-unit id="verb/open" resname="open">
+        <source>Open</source>
+        <context-group purpose="information">
+          <context context-type="x-context">verb</context>
+        </context-group>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+		if string(xliffBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(xliffBytes))
+		}
+	})
+
+	t.Run("exported xliff can be loaded again", func(t *testing.T) {
+		xliffBytes, err := MarshalXliff(translations, "en", "es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromXliffBytes(xliffBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]TranslateStrings{
+			"en": {
+				{Key: "emails", One: "One email", Many: "Many emails"},
+				{Key: "hello", Default: "Hello <b>{{.Name}}</b>", Description: "Greeting", MaxLength: 30},
+				{Key: "open", Context: "verb", Default: "Open"},
+				{Key: "spanish_only"},
+			},
+			"es": {
+				{Key: "emails", One: "Un correo", Many: "Muchos correos", Status: StatusFuzzy},
+				{Key: "hello", Default: "Hola <b>{{.Name}}</b>", Description: "Greeting", MaxLength: 30, Status: StatusApproved},
+				{Key: "spanish_only", Default: "Solo español"},
+			},
+		}
+		if !reflect.DeepEqual(loaded, expected) {
+			t.Errorf("Unexpected result: %#v", loaded)
+		}
+	})
+
+	t.Run("keep the tags and the status", func(t *testing.T) {
+		translations := map[string]TranslateStrings{
+			"en": {
+				{Key: "pay", Default: "Pay", Tags: []string{"checkout", "button"}, Status: StatusApproved},
+				{Key: "emails", One: "One email", Many: "Many emails", Description: "Inbox", Tags: []string{"inbox"}},
+			},
+			"es": {
+				{Key: "pay", Default: "Pagar", Status: StatusNeedsReview},
+				{Key: "emails", One: "Un correo", Many: "Muchos correos"},
+			},
+		}
+		xliffBytes, err := MarshalXliff(translations, "en", "es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(string(xliffBytes), `<note from="x-tags">checkout,button</note>`) ||
+			!strings.Contains(string(xliffBytes), `<note from="x-status">approved</note>`) {
+			t.Errorf("Unexpected result: %s", string(xliffBytes))
+		}
+
+		loaded, err := LoadFromXliffBytes(xliffBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := map[string]TranslateStrings{
+			"en": {
+				{Key: "emails", One: "One email", Many: "Many emails", Description: "Inbox", Tags: []string{"inbox"}},
+				{Key: "pay", Default: "Pay", Tags: []string{"checkout", "button"}, Status: StatusApproved},
+			},
+			"es": {
+				{Key: "emails", One: "Un correo", Many: "Muchos correos", Description: "Inbox", Tags: []string{"inbox"}},
+				{Key: "pay", Default: "Pagar", Tags: []string{"checkout", "button"}, Status: StatusNeedsReview},
+			},
+		}
+		if !reflect.DeepEqual(loaded, expected) {
+			t.Errorf("Unexpected result: %#v", loaded)
+		}
+	})
+
+	t.Run("handle unknown languages", func(t *testing.T) {
+		if _, err := MarshalXliff(translations, "xxx", ""); err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+		if _, err := MarshalXliff(translations, "en", "xxx"); err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
+
+func TestExportXliff(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	xliffBytes, err := i18n.ExportXliff("en", "es")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, _ := LoadFromXliffBytes(xliffBytes)
+	if len(loaded["es"]) != 1 || loaded["es"][0].Default != "Hola" {
+		t.Errorf("Unexpected result: %s", string(xliffBytes))
+	}
+}
@@ -0,0 +1,70 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYaml serializes a list of TranslateString to YAML in the
+// same format that is used by the YAML loaders.
+//
+// The translations are sorted by key and the empty
+// fields are omitted, so the output is stable.
+func MarshalYaml(
+	translateStrings TranslateStrings,
+) ([]byte, error) {
+	sorted := sortTranslateStrings(translateStrings)
+
+	// An empty list is encoded as [] to keep it loadable
+	if len(sorted) == 0 {
+		return []byte("[]\n"), nil
+	}
+
+	b := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(b)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// ExportLanguageYaml serializes the translations of a
+// loaded language to YAML using MarshalYaml.
+func (t *I18n) ExportLanguageYaml(
+	languageName string,
+) ([]byte, error) {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil, fmt.Errorf("goeasyi18n: the language '%s' doesn't exist", languageName)
+	}
+
+	return MarshalYaml(lang)
+}
+
+// ExportYaml serializes the translations of all the loaded
+// languages to YAML using MarshalYaml, it returns a map
+// with the language name as the key.
+func (t *I18n) ExportYaml() (map[string][]byte, error) {
+	exported := make(map[string][]byte, len(t.languages))
+
+	for languageName := range t.languages {
+		yamlBytes, err := t.ExportLanguageYaml(languageName)
+		if err != nil {
+			return nil, err
+		}
+		exported[languageName] = yamlBytes
+	}
+
+	return exported, nil
+}
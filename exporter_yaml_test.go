@@ -0,0 +1,88 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalYaml(t *testing.T) {
+	t.Run("sort by key and omit empty variants", func(t *testing.T) {
+		yamlBytes, err := MarshalYaml(TranslateStrings{
+			{Key: "world", Default: "World"},
+			{Key: "hello", One: "One", Many: "Many: {{.Qty}}"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `- Key: hello
+  One: One
+  Many: 'Many: {{.Qty}}'
+- Key: world
+  Default: World
+`
+		if string(yamlBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(yamlBytes))
+		}
+	})
+
+	t.Run("marshal empty list", func(t *testing.T) {
+		yamlBytes, err := MarshalYaml(TranslateStrings{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(yamlBytes) != "[]\n" {
+			t.Errorf("Unexpected result: %s", string(yamlBytes))
+		}
+	})
+
+	t.Run("exported yaml can be loaded again", func(t *testing.T) {
+		original := TranslateStrings{
+			{Key: "hello", Default: "Hello\n{{.Name}}", ManyNonBinary: "Hi all", Description: "Greeting", MaxLength: 20, Tags: []string{"home"}, ScreenshotRef: "home.png"},
+		}
+		yamlBytes, err := MarshalYaml(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		loaded, err := LoadFromYamlBytes(yamlBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(loaded) != 1 || !reflect.DeepEqual(loaded[0], original[0]) {
+			t.Errorf("Unexpected result: %v", loaded)
+		}
+	})
+}
+
+func TestExportYaml(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+	i18n.AddLanguage("es", TranslateStrings{{Key: "hello", Default: "Hola"}})
+
+	t.Run("export a single language", func(t *testing.T) {
+		yamlBytes, err := i18n.ExportLanguageYaml("es")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(yamlBytes) != "- Key: hello\n  Default: Hola\n" {
+			t.Errorf("Unexpected result: %s", string(yamlBytes))
+		}
+	})
+
+	t.Run("export all languages", func(t *testing.T) {
+		exported, err := i18n.ExportYaml()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(exported) != 2 || exported["en"] == nil || exported["es"] == nil {
+			t.Errorf("Unexpected result: %v", exported)
+		}
+	})
+
+	t.Run("handle unknown language", func(t *testing.T) {
+		_, err := i18n.ExportLanguageYaml("xxx")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,949 @@
+package goeasyi18n
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FluentResource is a parsed Mozilla Fluent (.ftl) resource with
+// its messages and terms, it can be added to the i18n object
+// using AddFluentLanguage
+type FluentResource struct {
+	messages map[string]*fluentEntry
+	terms    map[string]*fluentEntry
+}
+
+func newFluentResource() *FluentResource {
+	return &FluentResource{
+		messages: map[string]*fluentEntry{},
+		terms:    map[string]*fluentEntry{},
+	}
+}
+
+// MessageIDs returns the IDs of the messages of the resource
+// in the order they were declared
+func (r *FluentResource) MessageIDs() []string {
+	ids := make([]string, len(r.messages))
+	for id, message := range r.messages {
+		ids[message.index] = id
+	}
+	return ids
+}
+
+// merge adds the messages and terms of other resource,
+// it fails if an ID is declared in both resources
+func (r *FluentResource) merge(other *FluentResource) error {
+	for _, id := range other.MessageIDs() {
+		message := other.messages[id]
+		if _, exists := r.messages[id]; exists {
+			return fmt.Errorf("fluent: the message '%s' is defined more than once", id)
+		}
+		message.index = len(r.messages)
+		r.messages[id] = message
+	}
+	for id, term := range other.terms {
+		if _, exists := r.terms[id]; exists {
+			return fmt.Errorf("fluent: the term '-%s' is defined more than once", id)
+		}
+		r.terms[id] = term
+	}
+	return nil
+}
+
+// AddFluentLanguage adds a language to the i18n object with its
+// translations as a Mozilla Fluent resource.
+//
+// The messages are used by Translate when the key is not found in
+// the TranslateStrings of the language, "message.attribute" keys
+// translate the attributes of the messages. The Count and Gender
+// options are available as the "$count" and "$gender" variables
+// and the Data fields are available by their names.
+func (t *I18n) AddFluentLanguage(
+	languageName string,
+	resource *FluentResource,
+) {
+	t.fluentResources[languageName] = resource
+	if _, exists := t.pluralizationFuncs[languageName]; !exists {
+		t.SetPluralizationFunc(languageName, DefaultPluralizationFunc)
+	}
+}
+
+// HasFluentLanguage checks if a language has a Fluent resource,
+// its messages are not part of the TranslateStrings of the language
+func (t *I18n) HasFluentLanguage(languageName string) bool {
+	_, exists := t.fluentResources[languageName]
+	return exists
+}
+
+// translateFluent translates a message using the
+// Fluent resource of the language if there is one
+func (t *I18n) translateFluent(
+	languageName string,
+	translateKey string,
+	options Options,
+) (string, bool) {
+	resource, exists := t.fluentResources[languageName]
+	if !exists {
+		return "", false
+	}
+
+	return formatFluent(
+		resource,
+		translateKey,
+		t.pluralizationFunc(languageName),
+		options,
+	)
+}
+
+// fluentEntry is a message or a term
+type fluentEntry struct {
+	index      int
+	value      fluentPattern
+	attributes map[string]fluentPattern
+}
+
+// fluentPattern is a list of elements, every element
+// is a string or a fluentExpression
+type fluentPattern []any
+
+type fluentExpression interface{}
+
+type fluentStringLiteral struct{ value string }
+
+type fluentNumberLiteral struct{ value float64 }
+
+type fluentVariableReference struct{ name string }
+
+type fluentMessageReference struct{ id, attribute string }
+
+type fluentTermReference struct {
+	id        string
+	attribute string
+	arguments map[string]fluentExpression
+}
+
+type fluentFunctionReference struct {
+	name       string
+	positional []fluentExpression
+}
+
+type fluentSelectExpression struct {
+	selector fluentExpression
+	variants []fluentVariant
+}
+
+type fluentVariant struct {
+	key       string
+	isNumber  bool
+	number    float64
+	isDefault bool
+	value     fluentPattern
+}
+
+// parseFluent parses the subset of the Fluent syntax that is
+// supported by the library: messages, terms, attributes,
+// comments, select expressions, string and number literals,
+// variable, message and term references (with named arguments)
+// and the NUMBER function
+func parseFluent(src string) (*FluentResource, error) {
+	p := &fluentParser{
+		src: strings.ReplaceAll(src, "\r\n", "\n"),
+	}
+	return p.parseResource()
+}
+
+type fluentParser struct {
+	src string
+	pos int
+}
+
+func (p *fluentParser) errorf(format string, args ...any) error {
+	return &LoadError{
+		Position: positionAt([]byte(p.src), p.pos),
+		Err:      fmt.Errorf("fluent: "+format, args...),
+	}
+}
+
+func (p *fluentParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *fluentParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *fluentParser) skipInlineSpaces() {
+	for p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// skipBlank skips spaces and new lines
+func (p *fluentParser) skipBlank() {
+	for p.peek() == ' ' || p.peek() == '\n' {
+		p.pos++
+	}
+}
+
+func (p *fluentParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+func isFluentIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isFluentIdentifierChar(c byte) bool {
+	return isFluentIdentifierStart(c) || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *fluentParser) parseIdentifier() (string, error) {
+	if !isFluentIdentifierStart(p.peek()) {
+		return "", p.errorf("expected an identifier")
+	}
+	start := p.pos
+	for !p.eof() && isFluentIdentifierChar(p.peek()) {
+		p.pos++
+	}
+	return p.src[start:p.pos], nil
+}
+
+func (p *fluentParser) parseResource() (*FluentResource, error) {
+	resource := newFluentResource()
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return resource, nil
+		}
+
+		// Comments (#, ## and ###) are ignored
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		isTerm := false
+		if p.peek() == '-' {
+			isTerm = true
+			p.pos++
+		}
+
+		id, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := p.parseEntry()
+		if err != nil {
+			return nil, err
+		}
+
+		if isTerm {
+			if entry.value == nil {
+				return nil, p.errorf("the term '-%s' must have a value", id)
+			}
+			if _, exists := resource.terms[id]; exists {
+				return nil, p.errorf("the term '-%s' is defined more than once", id)
+			}
+			resource.terms[id] = entry
+			continue
+		}
+
+		if entry.value == nil && len(entry.attributes) == 0 {
+			return nil, p.errorf("the message '%s' must have a value or attributes", id)
+		}
+		if _, exists := resource.messages[id]; exists {
+			return nil, p.errorf("the message '%s' is defined more than once", id)
+		}
+		entry.index = len(resource.messages)
+		resource.messages[id] = entry
+	}
+}
+
+func (p *fluentParser) parseEntry() (*fluentEntry, error) {
+	p.skipInlineSpaces()
+	if p.peek() != '=' {
+		return nil, p.errorf("expected '='")
+	}
+	p.pos++
+
+	value, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &fluentEntry{
+		value:      value,
+		attributes: map[string]fluentPattern{},
+	}
+
+	for {
+		// Attributes are indented lines starting with "."
+		lineStart := p.pos
+		p.skipBlank()
+		if p.pos == lineStart || p.peek() != '.' || p.src[p.pos-1] != ' ' {
+			p.pos = lineStart
+			return entry, nil
+		}
+		p.pos++
+
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		p.skipInlineSpaces()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected '=' after the attribute '%s'", name)
+		}
+		p.pos++
+
+		attribute, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+		if attribute == nil {
+			return nil, p.errorf("the attribute '%s' must have a value", name)
+		}
+		entry.attributes[name] = attribute
+	}
+}
+
+// continuesPattern checks if the pattern continues after the new line
+// at the current position, that happens when the next non blank line
+// is indented and doesn't start with a special character
+func (p *fluentParser) continuesPattern() bool {
+	i := p.pos
+	for i < len(p.src) && (p.src[i] == '\n' || p.src[i] == ' ') {
+		i++
+	}
+	if i >= len(p.src) || p.src[i-1] != ' ' {
+		return false
+	}
+	switch p.src[i] {
+	case '.', '[', '*', '}':
+		return false
+	}
+	return true
+}
+
+// parsePattern parses a pattern until the end of the line
+// or the last indented line that continues it, it returns nil
+// if the pattern is empty
+func (p *fluentParser) parsePattern() (fluentPattern, error) {
+	p.skipInlineSpaces()
+
+	var pattern fluentPattern
+	var text strings.Builder
+
+	// Block patterns start in the next line
+	if p.peek() == '\n' && p.continuesPattern() {
+		p.skipBlank()
+	}
+
+	flushText := func() {
+		if text.Len() > 0 {
+			pattern = append(pattern, text.String())
+			text.Reset()
+		}
+	}
+
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '\n' {
+			if !p.continuesPattern() {
+				break
+			}
+			for p.peek() == '\n' || p.peek() == ' ' {
+				if p.peek() == '\n' {
+					text.WriteByte('\n')
+				}
+				p.pos++
+			}
+			continue
+		}
+
+		if c == '}' {
+			return nil, p.errorf("unbalanced closing brace")
+		}
+
+		if c == '{' {
+			p.pos++
+			expression, err := p.parsePlaceable()
+			if err != nil {
+				return nil, err
+			}
+			flushText()
+			pattern = append(pattern, expression)
+			continue
+		}
+
+		text.WriteByte(c)
+		p.pos++
+	}
+
+	flushText()
+
+	// Trailing whitespace is not part of the pattern
+	if len(pattern) > 0 {
+		if last, ok := pattern[len(pattern)-1].(string); ok {
+			last = strings.TrimRight(last, " \n")
+			if last == "" {
+				pattern = pattern[:len(pattern)-1]
+			} else {
+				pattern[len(pattern)-1] = last
+			}
+		}
+	}
+
+	if len(pattern) == 0 {
+		return nil, nil
+	}
+	return pattern, nil
+}
+
+// parsePlaceable parses the content of a placeable,
+// the opening brace must be already consumed
+func (p *fluentParser) parsePlaceable() (fluentExpression, error) {
+	p.skipBlank()
+
+	expression, err := p.parseInlineExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if strings.HasPrefix(p.src[p.pos:], "->") {
+		p.pos += 2
+		expression, err = p.parseVariants(expression)
+		if err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+	}
+
+	if p.peek() != '}' {
+		return nil, p.errorf("expected '}'")
+	}
+	p.pos++
+
+	return expression, nil
+}
+
+func (p *fluentParser) parseVariants(
+	selector fluentExpression,
+) (fluentExpression, error) {
+	selectExpression := fluentSelectExpression{selector: selector}
+	hasDefault := false
+
+	for {
+		p.skipBlank()
+		if p.peek() == '}' {
+			break
+		}
+
+		variant := fluentVariant{}
+		if p.peek() == '*' {
+			if hasDefault {
+				return nil, p.errorf("a select expression can have only one default variant")
+			}
+			variant.isDefault = true
+			hasDefault = true
+			p.pos++
+		}
+		if p.peek() != '[' {
+			return nil, p.errorf("expected a variant")
+		}
+		p.pos++
+		p.skipInlineSpaces()
+
+		start := p.pos
+		for !p.eof() && p.peek() != ']' && p.peek() != '\n' {
+			p.pos++
+		}
+		if p.peek() != ']' {
+			return nil, p.errorf("expected ']' to close the variant key")
+		}
+		variant.key = strings.TrimSpace(p.src[start:p.pos])
+		p.pos++
+		if number, err := strconv.ParseFloat(variant.key, 64); err == nil {
+			variant.isNumber = true
+			variant.number = number
+		}
+
+		value, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+		variant.value = value
+		selectExpression.variants = append(selectExpression.variants, variant)
+	}
+
+	if !hasDefault {
+		return nil, p.errorf("a select expression must have a default variant")
+	}
+
+	return selectExpression, nil
+}
+
+func (p *fluentParser) parseInlineExpression() (fluentExpression, error) {
+	c := p.peek()
+
+	switch {
+	case c == '"':
+		return p.parseStringLiteral()
+
+	case c >= '0' && c <= '9' ||
+		c == '-' && p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9':
+		start := p.pos
+		p.pos++
+		for !p.eof() && (p.peek() >= '0' && p.peek() <= '9' || p.peek() == '.') {
+			p.pos++
+		}
+		number, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number '%s'", p.src[start:p.pos])
+		}
+		return fluentNumberLiteral{value: number}, nil
+
+	case c == '$':
+		p.pos++
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		return fluentVariableReference{name: name}, nil
+
+	case c == '{':
+		p.pos++
+		return p.parsePlaceable()
+
+	case c == '-':
+		p.pos++
+		id, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		term := fluentTermReference{id: id}
+		if p.peek() == '.' {
+			p.pos++
+			term.attribute, err = p.parseIdentifier()
+			if err != nil {
+				return nil, err
+			}
+		}
+		p.skipBlank()
+		if p.peek() == '(' {
+			p.pos++
+			_, named, err := p.parseCallArguments()
+			if err != nil {
+				return nil, err
+			}
+			term.arguments = named
+		}
+		return term, nil
+	}
+
+	id, err := p.parseIdentifier()
+	if err != nil {
+		return nil, p.errorf("expected an expression")
+	}
+
+	if p.peek() == '(' {
+		p.pos++
+		if id != strings.ToUpper(id) {
+			return nil, p.errorf("invalid function name '%s'", id)
+		}
+		positional, _, err := p.parseCallArguments()
+		if err != nil {
+			return nil, err
+		}
+		return fluentFunctionReference{name: id, positional: positional}, nil
+	}
+
+	message := fluentMessageReference{id: id}
+	if p.peek() == '.' {
+		p.pos++
+		message.attribute, err = p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
+// parseCallArguments parses the arguments of a function or a term
+// call, the opening parenthesis must be already consumed
+func (p *fluentParser) parseCallArguments() (
+	[]fluentExpression,
+	map[string]fluentExpression,
+	error,
+) {
+	var positional []fluentExpression
+	named := map[string]fluentExpression{}
+
+	for {
+		p.skipBlank()
+		if p.peek() == ')' {
+			p.pos++
+			return positional, named, nil
+		}
+
+		expression, err := p.parseInlineExpression()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		p.skipBlank()
+		if message, ok := expression.(fluentMessageReference); ok && p.peek() == ':' {
+			p.pos++
+			p.skipBlank()
+			value, err := p.parseInlineExpression()
+			if err != nil {
+				return nil, nil, err
+			}
+			named[message.id] = value
+		} else {
+			positional = append(positional, expression)
+		}
+
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ')' {
+			return nil, nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+func (p *fluentParser) parseStringLiteral() (fluentExpression, error) {
+	p.pos++ // "
+	var sb strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return nil, p.errorf("unterminated string literal")
+		}
+		c := p.peek()
+		p.pos++
+		if c == '"' {
+			return fluentStringLiteral{value: sb.String()}, nil
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		switch p.peek() {
+		case '"', '\\':
+			sb.WriteByte(p.peek())
+			p.pos++
+		case 'u', 'U':
+			size := 4
+			if p.peek() == 'U' {
+				size = 6
+			}
+			p.pos++
+			if p.pos+size > len(p.src) {
+				return nil, p.errorf("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return nil, p.errorf("invalid unicode escape")
+			}
+			p.pos += size
+			sb.WriteRune(rune(code))
+		default:
+			return nil, p.errorf("invalid escape sequence")
+		}
+	}
+}
+
+// fluentMaxDepth limits the nesting of references
+// to avoid infinite loops with cyclic references
+const fluentMaxDepth = 100
+
+// fluentScope has everything that is needed to resolve a pattern
+type fluentScope struct {
+	resource          *FluentResource
+	pluralizationFunc PluralizationFunc
+	options           Options
+	arguments         map[string]fluentExpression
+	isTerm            bool
+	depth             int
+}
+
+// formatFluent resolves a message (or one of its attributes
+// using the "message.attribute" syntax) from the resource
+func formatFluent(
+	resource *FluentResource,
+	messageKey string,
+	pluralizationFunc PluralizationFunc,
+	options Options,
+) (string, bool) {
+	id, attribute, _ := strings.Cut(messageKey, ".")
+
+	message, exists := resource.messages[id]
+	if !exists {
+		return "", false
+	}
+
+	pattern := message.value
+	if attribute != "" {
+		pattern, exists = message.attributes[attribute]
+		if !exists {
+			return "", false
+		}
+	}
+	if pattern == nil {
+		return "", false
+	}
+
+	scope := &fluentScope{
+		resource:          resource,
+		pluralizationFunc: pluralizationFunc,
+		options:           options,
+	}
+	return scope.resolvePattern(pattern), true
+}
+
+func (s *fluentScope) resolvePattern(pattern fluentPattern) string {
+	var sb strings.Builder
+	for _, element := range pattern {
+		if text, ok := element.(string); ok {
+			sb.WriteString(text)
+			continue
+		}
+		sb.WriteString(fluentValueToString(s.resolveExpression(element)))
+	}
+	return sb.String()
+}
+
+// resolveExpression resolves an expression to a string or a float64
+func (s *fluentScope) resolveExpression(expression fluentExpression) any {
+	if s.depth > fluentMaxDepth {
+		return "{???}"
+	}
+
+	switch e := expression.(type) {
+	case fluentStringLiteral:
+		return e.value
+
+	case fluentNumberLiteral:
+		return e.value
+
+	case fluentVariableReference:
+		value, exists := s.variable(e.name)
+		if !exists {
+			return "{$" + e.name + "}"
+		}
+		return value
+
+	case fluentMessageReference:
+		message, exists := s.resource.messages[e.id]
+		if !exists {
+			return "{" + e.id + "}"
+		}
+		pattern := message.value
+		if e.attribute != "" {
+			pattern = message.attributes[e.attribute]
+		}
+		if pattern == nil {
+			return "{" + e.id + "}"
+		}
+		nested := *s
+		nested.depth++
+		return nested.resolvePattern(pattern)
+
+	case fluentTermReference:
+		term, exists := s.resource.terms[e.id]
+		if !exists {
+			return "{-" + e.id + "}"
+		}
+		pattern := term.value
+		if e.attribute != "" {
+			pattern = term.attributes[e.attribute]
+		}
+		if pattern == nil {
+			return "{-" + e.id + "}"
+		}
+
+		// Terms only see the arguments passed to them
+		arguments := map[string]fluentExpression{}
+		for name, argument := range e.arguments {
+			arguments[name] = fluentLiteral(s.resolveExpression(argument))
+		}
+		nested := *s
+		nested.depth++
+		nested.isTerm = true
+		nested.arguments = arguments
+		return nested.resolvePattern(pattern)
+
+	case fluentFunctionReference:
+		if e.name == "NUMBER" && len(e.positional) > 0 {
+			return fluentToNumber(s.resolveExpression(e.positional[0]))
+		}
+		return "{" + e.name + "()}"
+
+	case fluentSelectExpression:
+		selector := s.resolveExpression(e.selector)
+		nested := *s
+		nested.depth++
+		return nested.resolvePattern(s.selectVariant(e, selector).value)
+	}
+
+	return "{???}"
+}
+
+// selectVariant picks the variant that matches the selector, first
+// looking for an exact match, then for the plural category of numbers
+// and finally falling back to the default variant
+func (s *fluentScope) selectVariant(
+	e fluentSelectExpression,
+	selector any,
+) fluentVariant {
+	number, isNumber := selector.(float64)
+
+	for _, variant := range e.variants {
+		if isNumber && variant.isNumber && variant.number == number {
+			return variant
+		}
+		if !isNumber && !variant.isNumber && variant.key == fluentValueToString(selector) {
+			return variant
+		}
+	}
+
+	if isNumber && s.pluralizationFunc != nil {
+		category := strings.ToLower(s.pluralizationFunc(int(number)))
+		for _, variant := range e.variants {
+			if variant.key == category {
+				return variant
+			}
+		}
+	}
+
+	for _, variant := range e.variants {
+		if variant.isDefault {
+			return variant
+		}
+	}
+	return e.variants[len(e.variants)-1]
+}
+
+// variable gets the value of a variable, "$count" and "$gender"
+// come from the Count and Gender options and the other
+// variables come from the Data option
+func (s *fluentScope) variable(name string) (any, bool) {
+	if s.isTerm {
+		argument, exists := s.arguments[name]
+		if !exists {
+			return nil, false
+		}
+		return s.resolveExpression(argument), true
+	}
+
+	if name == "count" && s.options.Count != nil {
+		return float64(*s.options.Count), true
+	}
+
+	if name == "gender" && s.options.Gender != nil {
+		gender := strings.ToLower(createGenderForm(*s.options.Gender))
+		if gender == "" {
+			gender = strings.ToLower(*s.options.Gender)
+		}
+		return gender, true
+	}
+
+	value, exists := lookupData(s.options.Data, name)
+	if !exists {
+		return nil, false
+	}
+	return fluentToValue(value), true
+}
+
+// lookupData gets a value by name from a map or a struct, the
+// name is also looked up with its first letter in uppercase so
+// "$name" can reference the exported field "Name"
+func lookupData(data any, name string) (any, bool) {
+	if data == nil {
+		return nil, false
+	}
+
+	names := []string{name}
+	if capitalized := strings.ToUpper(name[:1]) + name[1:]; capitalized != name {
+		names = append(names, capitalized)
+	}
+
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+
+	for _, n := range names {
+		switch value.Kind() {
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			item := value.MapIndex(reflect.ValueOf(n).Convert(value.Type().Key()))
+			if item.IsValid() {
+				return item.Interface(), true
+			}
+		case reflect.Struct:
+			field := value.FieldByName(n)
+			if field.IsValid() && field.CanInterface() {
+				return field.Interface(), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// fluentToValue converts a Go value to a Fluent value,
+// numbers are float64 and everything else is a string
+func fluentToValue(value any) any {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return fmt.Sprint(value)
+}
+
+func fluentToNumber(value any) any {
+	if s, ok := value.(string); ok {
+		if number, err := strconv.ParseFloat(s, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+func fluentLiteral(value any) fluentExpression {
+	if number, ok := value.(float64); ok {
+		return fluentNumberLiteral{value: number}
+	}
+	return fluentStringLiteral{value: fluentValueToString(value)}
+}
+
+func fluentValueToString(value any) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
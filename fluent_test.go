@@ -0,0 +1,205 @@
+package goeasyi18n
+
+import "testing"
+
+const fluentTestResource = `
+### Resource comment
+
+## Group comment
+-brand = Easy App
+    .gender = female
+
+# Simple messages and references
+hello = Hello { $name }
+welcome = Welcome to { -brand }
+about = { welcome }, { hello }!
+literals = { "{" }{ 42 }{ "}" }
+
+login-input = Predefined value
+    .placeholder = email@example.com
+    .aria-label = Login input value
+
+multiline =
+    First line
+    Second line
+
+emails = { $count ->
+    [0] You have no emails
+    [one] You have one email
+   *[other] You have { $count } emails
+}
+
+greeting = { $gender ->
+    [male] Welcome, sir
+    [female] Welcome, ma'am
+   *[other] Welcome
+}
+
+role = { $role ->
+    [admin] Hello admin
+   *[other] Hello user
+}
+
+brand-gender = { -brand.gender ->
+    [female] She is { -brand }
+   *[other] It is { -brand }
+}
+
+-thing = { $case ->
+    [lower] thing
+   *[upper] Thing
+}
+thing-lower = A { -thing(case: "lower") }
+thing-upper = { -thing(case: "upper") } here
+
+number = { NUMBER($amount) ->
+    [1] Exactly one
+   *[other] Other amount
+}
+
+missing = Hello { $missing } and { nothing }
+`
+
+func TestFluent(t *testing.T) {
+	resource, err := LoadFromFluentString(fluentTestResource)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	i18n := NewI18n()
+	i18n.AddFluentLanguage("en", resource)
+
+	tests := []struct {
+		key      string
+		options  Options
+		expected string
+	}{
+		{"hello", Options{Data: Data{"name": "John"}}, "Hello John"},
+		{"hello", Options{Data: struct{ Name string }{Name: "Jane"}}, "Hello Jane"},
+		{"welcome", Options{}, "Welcome to Easy App"},
+		{"about", Options{Data: map[string]string{"name": "John"}}, "Welcome to Easy App, Hello John!"},
+		{"literals", Options{}, "{42}"},
+		{"login-input", Options{}, "Predefined value"},
+		{"login-input.placeholder", Options{}, "email@example.com"},
+		{"login-input.aria-label", Options{}, "Login input value"},
+		{"login-input.xxx", Options{}, ""},
+		{"multiline", Options{}, "First line\nSecond line"},
+		{"emails", Options{Count: createPtr(0)}, "You have no emails"},
+		{"emails", Options{Count: createPtr(1)}, "You have one email"},
+		{"emails", Options{Count: createPtr(5)}, "You have 5 emails"},
+		{"greeting", Options{Gender: createPtr("Male")}, "Welcome, sir"},
+		{"greeting", Options{Gender: createPtr("female")}, "Welcome, ma'am"},
+		{"greeting", Options{Gender: createPtr("non-binary")}, "Welcome"},
+		{"greeting", Options{}, "Welcome"},
+		{"role", Options{Data: Data{"role": "admin"}}, "Hello admin"},
+		{"role", Options{Data: Data{"role": "guest"}}, "Hello user"},
+		{"brand-gender", Options{}, "She is Easy App"},
+		{"thing-lower", Options{}, "A thing"},
+		{"thing-upper", Options{}, "Thing here"},
+		{"number", Options{Data: Data{"amount": 1}}, "Exactly one"},
+		{"number", Options{Data: Data{"amount": "1"}}, "Exactly one"},
+		{"number", Options{Data: Data{"amount": 2}}, "Other amount"},
+		{"missing", Options{}, "Hello {$missing} and {nothing}"},
+		{"xxx", Options{}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			got := i18n.Translate("en", test.key, test.options)
+			if got != test.expected {
+				t.Errorf("expected %q; got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFluentIntegration(t *testing.T) {
+	t.Run("fluent messages are used with the custom pluralization function", func(t *testing.T) {
+		resource, _ := LoadFromFluentString(`
+apples = { $count ->
+    [one] One apple
+    [few] A few apples
+   *[other] Many apples
+}
+`)
+
+		i18n := NewI18n()
+		i18n.AddFluentLanguage("xx", resource)
+		i18n.SetPluralizationFunc("xx", func(count int) string {
+			if count == 1 {
+				return "One"
+			}
+			if count < 5 {
+				return "Few"
+			}
+			return "Many"
+		})
+
+		if !i18n.HasFluentLanguage("xx") || i18n.HasFluentLanguage("en") {
+			t.Errorf("Unexpected result of HasFluentLanguage")
+		}
+
+		tests := map[int]string{1: "One apple", 3: "A few apples", 10: "Many apples"}
+		for count, expected := range tests {
+			got := i18n.Translate("xx", "apples", Options{Count: createPtr(count)})
+			if got != expected {
+				t.Errorf("expected %q; got %q", expected, got)
+			}
+		}
+	})
+
+	t.Run("translate strings take precedence and fallback works", func(t *testing.T) {
+		enResource, _ := LoadFromFluentString("hello = Hello from fluent\nonly-en = Only english\n")
+		esResource, _ := LoadFromFluentString("hello = Hola desde fluent\n")
+
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "hello", Default: "Hello"},
+		})
+		i18n.AddFluentLanguage("en", enResource)
+		i18n.AddFluentLanguage("es", esResource)
+
+		tests := []struct {
+			lang     string
+			key      string
+			expected string
+		}{
+			{"en", "hello", "Hello"},
+			{"es", "hello", "Hola desde fluent"},
+			{"es", "only-en", "Only english"},
+			{"xxx", "only-en", "Only english"},
+		}
+
+		for _, test := range tests {
+			got := i18n.Translate(test.lang, test.key)
+			if got != test.expected {
+				t.Errorf("expected %q; got %q", test.expected, got)
+			}
+		}
+
+		if !i18n.HasLanguage("es") {
+			t.Errorf("expected language es to exist")
+		}
+	})
+
+	t.Run("handle incorrect fluent syntax", func(t *testing.T) {
+		tests := []string{
+			"hello",
+			"hello =",
+			"-term = \n",
+			"hello = { $name",
+			"hello = }",
+			"hello = { $x ->\n    [a] A\n}",
+			"hello = { $x ->\n   *[a] A\n   *[b] B\n}",
+			"hello = Hello\nhello = Again",
+			`hello = { "unterminated }`,
+		}
+
+		for _, test := range tests {
+			_, err := LoadFromFluentString(test)
+			if err == nil {
+				t.Errorf("Expected error for %q, got nil", test)
+			}
+		}
+	})
+}
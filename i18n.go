@@ -3,15 +3,28 @@ package goeasyi18n
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type I18n struct {
 	languages               map[string]TranslateStrings
+	keyIndexes              map[string]map[string]int
 	pluralizationFuncs      map[string]PluralizationFunc
+	pluralRules             map[string]*PluralRule
+	fluentResources         map[string]*FluentResource
+	tenantOverrides         map[string]map[string]TranslateStrings
+	namespaces              map[string]*I18n
+	namespaceName           string
 	fallbackLanguageName    string
 	disableConsistencyCheck bool
+	duplicateKeyPolicy      DuplicateKeyPolicy
+	strictLoading           bool
+	collectLoadErrors       bool
+	keyAsFallback           bool
+	fuzzyAsMissing          bool
+	onKeyEvent              func(event KeyEvent)
 }
 
 // Config is used to configure the i18n object
@@ -20,6 +33,28 @@ type Config struct {
 	FallbackLanguageName string
 	// Default: false
 	DisableConsistencyCheck bool
+	// Used by LoadDir and LoadFS when a key is defined more than once
+	// for the same language. Default: DuplicateKeyPolicyError
+	DuplicateKeyPolicy DuplicateKeyPolicy
+	// Makes LoadDir and LoadFS reject the unknown fields
+	// of the translations. Default: false
+	StrictLoading bool
+	// Makes LoadDir and LoadFS add the catalogs despite the unknown
+	// fields (with StrictLoading) and the duplicated keys (with
+	// DuplicateKeyPolicyError, the first definition is kept), and
+	// return all of them together as a *LoadErrors, so the tools
+	// that check the catalogs get every problem. Default: false
+	CollectLoadErrors bool
+	// Uses the key as the translation when it is not found in any
+	// language, so the source text can be used as the key like in
+	// gettext: i18n.T("es", "You have {{.Qty}} messages"). Default: false
+	KeyAsFallback bool
+	// Ignores the translations with the fuzzy status, so the fallback
+	// language is used instead. Default: false
+	FuzzyAsMissing bool
+	// Called when a key is missing or deprecated, it can be used to
+	// log them and migrate the code gradually. Default: nil
+	OnKeyEvent func(event KeyEvent)
 }
 
 // NewI18n creates and returns a new i18n object
@@ -33,8 +68,19 @@ func NewI18n(config ...Config) *I18n {
 
 	instance := I18n{
 		languages:               make(map[string]TranslateStrings),
+		keyIndexes:              make(map[string]map[string]int),
 		pluralizationFuncs:      make(map[string]PluralizationFunc),
+		pluralRules:             make(map[string]*PluralRule),
+		fluentResources:         make(map[string]*FluentResource),
+		tenantOverrides:         make(map[string]map[string]TranslateStrings),
+		namespaces:              make(map[string]*I18n),
 		disableConsistencyCheck: pickedConfig.DisableConsistencyCheck,
+		duplicateKeyPolicy:      pickedConfig.DuplicateKeyPolicy,
+		strictLoading:           pickedConfig.StrictLoading,
+		collectLoadErrors:       pickedConfig.CollectLoadErrors,
+		keyAsFallback:           pickedConfig.KeyAsFallback,
+		fuzzyAsMissing:          pickedConfig.FuzzyAsMissing,
+		onKeyEvent:              pickedConfig.OnKeyEvent,
 	}
 
 	if pickedConfig.FallbackLanguageName != "" {
@@ -82,7 +128,7 @@ func createGenderForm(input string) string {
 
 // CheckLanguageConsistency checks if a language is consistent
 // with the other languages, it checks if the translations keys
-// are the same in all languages
+// are the same in all languages (the aliases are ignored)
 func (t *I18n) CheckLanguageConsistency(
 	langNameToCheck string,
 ) (bool, []string) {
@@ -93,6 +139,11 @@ func (t *I18n) CheckLanguageConsistency(
 		}
 	}
 
+	prefix := "goeasyi18n: "
+	if t.namespaceName != "" {
+		prefix += "namespace '" + t.namespaceName + "': "
+	}
+
 	inconsistencies := []string{}
 
 	for langName, lang := range t.languages {
@@ -103,9 +154,13 @@ func (t *I18n) CheckLanguageConsistency(
 		// Check if the new language has more keys
 		// than existing languages
 		for _, translateStringToCheck := range langToCheck {
+			if translateStringToCheck.AliasOf != "" {
+				continue
+			}
 			found := false
 			for _, translateString := range lang {
-				if translateString.Key == translateStringToCheck.Key {
+				if translateString.Key == translateStringToCheck.Key &&
+					translateString.Context == translateStringToCheck.Context {
 					found = true
 					break
 				}
@@ -114,9 +169,10 @@ func (t *I18n) CheckLanguageConsistency(
 				inconsistencies = append(
 					inconsistencies,
 					fmt.Sprintf(
-						"goeasyi18n: the language '%s' has the key '%s' that doesn't exist in '%s'",
+						"%sthe language '%s' has the key %s that doesn't exist in '%s'",
+						prefix,
 						langNameToCheck,
-						translateStringToCheck.Key,
+						describeKey(translateStringToCheck.Key, translateStringToCheck.Context),
 						langName,
 					),
 				)
@@ -126,9 +182,13 @@ func (t *I18n) CheckLanguageConsistency(
 		// Check if the new language has less keys
 		// than existing languages
 		for _, translateString := range lang {
+			if translateString.AliasOf != "" {
+				continue
+			}
 			found := false
 			for _, translateStringToCheck := range langToCheck {
-				if translateString.Key == translateStringToCheck.Key {
+				if translateString.Key == translateStringToCheck.Key &&
+					translateString.Context == translateStringToCheck.Context {
 					found = true
 					break
 				}
@@ -137,9 +197,10 @@ func (t *I18n) CheckLanguageConsistency(
 				inconsistencies = append(
 					inconsistencies,
 					fmt.Sprintf(
-						"goeasyi18n: the language '%s' has the key '%s' that doesn't exist in '%s'",
+						"%sthe language '%s' has the key %s that doesn't exist in '%s'",
+						prefix,
 						langName,
-						translateString.Key,
+						describeKey(translateString.Key, translateString.Context),
 						langNameToCheck,
 					),
 				)
@@ -161,9 +222,50 @@ func (t *I18n) CheckLanguageConsistency(
 func (t *I18n) AddLanguage(
 	languageName string,
 	translateStrings TranslateStrings,
+) []string {
+	return t.addLanguage(languageName, translateStrings, indexKeys(translateStrings))
+}
+
+// addLanguage is AddLanguage with the index of the keys already built
+func (t *I18n) addLanguage(
+	languageName string,
+	translateStrings TranslateStrings,
+	keyIndex map[string]int,
 ) []string {
 	t.languages[languageName] = translateStrings
-	t.SetPluralizationFunc(languageName, DefaultPluralizationFunc)
+	t.keyIndexes[languageName] = keyIndex
+
+	// The pluralization functions are shared with the namespaces,
+	// so a namespace only sets the default one if it is missing
+	_, hasPluralizationFunc := t.pluralizationFuncs[languageName]
+	if t.namespaceName == "" || !hasPluralizationFunc {
+		t.SetPluralizationFunc(languageName, DefaultPluralizationFunc)
+	}
+
+	if t.disableConsistencyCheck == false {
+		isConsistent, errors := t.CheckLanguageConsistency(languageName)
+		if isConsistent == false {
+			errorMsg := strings.Join(errors, "\n")
+			fmt.Println(errorMsg)
+		}
+		return errors
+	}
+
+	return nil
+}
+
+// MergeLanguage adds the translations to a language, the keys that
+// already exist are overridden and the new keys are added, so it can
+// be used to layer catalogs over a base. The language is created if
+// it doesn't exist and its pluralization function is preserved.
+//
+// Like AddLanguage, after that it checks if the language is
+// consistent with the other languages (can be disabled with the config)
+func (t *I18n) MergeLanguage(
+	languageName string,
+	translateStrings TranslateStrings,
+) []string {
+	t.mergeTranslateStrings(languageName, translateStrings)
 
 	if t.disableConsistencyCheck == false {
 		isConsistent, errors := t.CheckLanguageConsistency(languageName)
@@ -177,22 +279,158 @@ func (t *I18n) AddLanguage(
 	return nil
 }
 
+// RemoveLanguage removes a language from the i18n object with its
+// translations, its Fluent resource and its pluralization function
+// (a namespace keeps the pluralization function because it is shared)
+func (t *I18n) RemoveLanguage(languageName string) {
+	delete(t.languages, languageName)
+	delete(t.keyIndexes, languageName)
+	delete(t.fluentResources, languageName)
+	if t.namespaceName == "" {
+		delete(t.pluralizationFuncs, languageName)
+		delete(t.pluralRules, languageName)
+	}
+}
+
+// SetTranslation adds or overrides a single translation of a language,
+// the language is created if it doesn't exist and its pluralization
+// function is preserved
+func (t *I18n) SetTranslation(
+	languageName string,
+	translateString TranslateString,
+) {
+	t.mergeTranslateStrings(languageName, TranslateStrings{translateString})
+}
+
+// RemoveTranslation removes a single translation of a language, the
+// context can be passed to remove a translation with context. It
+// returns false if the language or the key doesn't exist
+func (t *I18n) RemoveTranslation(
+	languageName string,
+	translateKey string,
+	translateContext ...string,
+) bool {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return false
+	}
+
+	var context string
+	if len(translateContext) > 0 {
+		context = translateContext[0]
+	}
+
+	for i, ts := range lang {
+		if ts.Key != translateKey || ts.Context != context {
+			continue
+		}
+		// A new slice is created so the slice passed
+		// to AddLanguage is not modified
+		removed := make(TranslateStrings, 0, len(lang)-1)
+		removed = append(removed, lang[:i]...)
+		removed = append(removed, lang[i+1:]...)
+		t.languages[languageName] = removed
+		t.keyIndexes[languageName] = indexKeys(removed)
+		return true
+	}
+
+	return false
+}
+
+// mergeTranslateStrings overrides the existing keys of a language and
+// adds the new ones, the default pluralization function is only set
+// if the language doesn't have one
+func (t *I18n) mergeTranslateStrings(
+	languageName string,
+	translateStrings TranslateStrings,
+) {
+	lang := t.languages[languageName]
+
+	// A new slice is created so the slice passed
+	// to AddLanguage is not modified
+	merged := make(TranslateStrings, len(lang), len(lang)+len(translateStrings))
+	copy(merged, lang)
+
+	indexes := make(map[string]int, len(merged))
+	for i, ts := range merged {
+		indexes[translationID(ts.Key, ts.Context)] = i
+	}
+
+	for _, ts := range translateStrings {
+		id := translationID(ts.Key, ts.Context)
+		if index, exists := indexes[id]; exists {
+			merged[index] = ts
+			continue
+		}
+		indexes[id] = len(merged)
+		merged = append(merged, ts)
+	}
+
+	t.languages[languageName] = merged
+	t.keyIndexes[languageName] = indexKeys(merged)
+	if _, exists := t.pluralizationFuncs[languageName]; !exists {
+		t.SetPluralizationFunc(languageName, DefaultPluralizationFunc)
+	}
+}
+
 // HasLanguage checks if a language is available (if is loaded)
 func (t *I18n) HasLanguage(languageName string) bool {
 	_, ok := t.languages[languageName]
-	return ok
+	_, okFluent := t.fluentResources[languageName]
+	return ok || okFluent
+}
+
+// LanguageNames returns the names of the loaded languages
+// sorted alphabetically
+func (t *I18n) LanguageNames() []string {
+	names := make([]string, 0, len(t.languages))
+	for name := range t.languages {
+		names = append(names, name)
+	}
+	for name := range t.fluentResources {
+		if _, exists := t.languages[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Translations returns a copy of the translations of a language,
+// or nil if the language doesn't exist
+func (t *I18n) Translations(languageName string) TranslateStrings {
+	lang, exists := t.languages[languageName]
+	if !exists {
+		return nil
+	}
+	translations := make(TranslateStrings, len(lang))
+	copy(translations, lang)
+	return translations
 }
 
 // SetPluralizationFunc sets the pluralization function for a language
 func (t *I18n) SetPluralizationFunc(languageName string, fn PluralizationFunc) {
 	t.pluralizationFuncs[languageName] = fn
+	delete(t.pluralRules, languageName)
+}
+
+// pluralizationFunc returns the pluralization function of a language,
+// the namespaces share them with the root, so it can be removed by the
+// root while the namespace still has the language
+func (t *I18n) pluralizationFunc(languageName string) PluralizationFunc {
+	if fn := t.pluralizationFuncs[languageName]; fn != nil {
+		return fn
+	}
+	return DefaultPluralizationFunc
 }
 
 // Options are the additional options for the Translate function
 type Options struct {
-	Data   any
-	Count  *int
-	Gender *string // male, female, nonbinary, non-binary (case insensitive)
+	Data    any
+	Count   *int
+	Gender  *string // male, female, nonbinary, non-binary (case insensitive)
+	Context string  // Optional, to disambiguate the same key (like the gettext msgctxt)
+	Tenant  string  // Optional, the tenant whose overrides are used first
 }
 
 // Translate translates a string in a specific language using a key
@@ -213,32 +451,48 @@ func (t *I18n) Translate(
 	// Get lang and fallback if not found
 	lang, okLang := t.languages[languageName]
 	fallbackLang, okFallbackLang := t.languages[t.fallbackLanguageName]
-	if !okLang && !okFallbackLang {
-		return ""
+	_, okFluentLang := t.fluentResources[languageName]
+	_, okFluentFallbackLang := t.fluentResources[t.fallbackLanguageName]
+	if !okLang && !okFallbackLang && !okFluentLang && !okFluentFallbackLang {
+		return t.missingTranslation(languageName, translateKey, translateKey, pickedOptions)
 	}
-	if !okLang {
+	if !okLang && !okFluentLang {
 		copy(lang, fallbackLang)
 		languageName = t.fallbackLanguageName
 	}
 
-	// Get the translate string from key or fallback if not found
-	var translateString TranslateString
-	for _, ts := range lang {
-		if ts.Key == translateKey {
-			translateString = ts
-			break
+	// The aliases are resolved to the key to be used
+	requestedKey := translateKey
+	translateKey = t.resolveKey(languageName, translateKey, pickedOptions.Context)
+
+	// Get the translate string from key or fallback if not found,
+	// the Fluent messages are used if the key is not found in
+	// the translate strings of the same language (Fluent doesn't
+	// have contexts, so they are only used without context)
+	translateString, _ := t.tenantTranslateString(pickedOptions.Tenant, languageName, translateKey, pickedOptions.Context)
+	if translateString.Key == "" && len(lang) > 0 {
+		translateString, _ = t.findTranslateString(languageName, translateKey, pickedOptions.Context)
+	}
+	if translateString.Key == "" && pickedOptions.Context == "" {
+		translation, found := t.translateFluent(languageName, translateKey, pickedOptions)
+		if found {
+			return translation
 		}
 	}
 	if translateString.Key == "" {
-		for _, ts := range fallbackLang {
-			if ts.Key == translateKey {
-				translateString = ts
-				break
-			}
+		translateString, _ = t.tenantTranslateString(pickedOptions.Tenant, t.fallbackLanguageName, translateKey, pickedOptions.Context)
+	}
+	if translateString.Key == "" && len(fallbackLang) > 0 {
+		translateString, _ = t.findTranslateString(t.fallbackLanguageName, translateKey, pickedOptions.Context)
+	}
+	if translateString.Key == "" && pickedOptions.Context == "" {
+		translation, found := t.translateFluent(t.fallbackLanguageName, translateKey, pickedOptions)
+		if found {
+			return translation
 		}
 	}
 	if translateString.Key == "" {
-		return ""
+		return t.missingTranslation(languageName, requestedKey, translateKey, pickedOptions)
 	}
 
 	// Get the string key to be used
@@ -256,7 +510,7 @@ func (t *I18n) Translate(
 	// Get the plural and gender forms to be used if needed
 	var pluralForm, genderForm string
 	if mode == "Pluralized" || mode == "PluralizedGendered" {
-		pluralizationFunc := t.pluralizationFuncs[languageName]
+		pluralizationFunc := t.pluralizationFunc(languageName)
 		pluralForm = pluralizationFunc(*pickedOptions.Count)
 	}
 	if mode == "Gendered" || mode == "PluralizedGendered" {
@@ -291,6 +545,73 @@ func (t *I18n) Translate(
 	return translation
 }
 
+// indexKeys builds the index of the keys of a language, the position
+// of the first translation of every key and context
+func indexKeys(translateStrings TranslateStrings) map[string]int {
+	keyIndex := make(map[string]int, len(translateStrings))
+	for i, ts := range translateStrings {
+		id := translationID(ts.Key, ts.Context)
+		if _, exists := keyIndex[id]; !exists {
+			keyIndex[id] = i
+		}
+	}
+	return keyIndex
+}
+
+// findTranslateString finds the first usable translation of a key
+// using the index of the keys of the language, the translations
+// after the indexed one are checked if it is not usable
+func (t *I18n) findTranslateString(
+	languageName string,
+	translateKey string,
+	context string,
+) (TranslateString, bool) {
+	lang := t.languages[languageName]
+	index, exists := t.keyIndexes[languageName][translationID(translateKey, context)]
+	if !exists || index >= len(lang) {
+		return TranslateString{}, false
+	}
+
+	for _, ts := range lang[index:] {
+		if ts.Key == translateKey && ts.Context == context && t.isUsable(ts) {
+			return ts, true
+		}
+	}
+	return TranslateString{}, false
+}
+
+// isUsable checks if a translation can be used, the fuzzy
+// translations are ignored with the FuzzyAsMissing config
+func (t *I18n) isUsable(translateString TranslateString) bool {
+	return !t.fuzzyAsMissing || translateString.Status != StatusFuzzy
+}
+
+// missingTranslation is the output for the keys that are not found in
+// any language, with the KeyAsFallback config the requested key itself
+// is used (it is executed as a template if there is data)
+func (t *I18n) missingTranslation(
+	languageName string,
+	translateKey string,
+	resolvedKey string,
+	options Options,
+) string {
+	t.emitKeyEvent(KeyEvent{
+		Type:         KeyEventMissing,
+		LanguageName: languageName,
+		Key:          translateKey,
+		Context:      options.Context,
+		ResolvedKey:  resolvedKey,
+	})
+
+	if !t.keyAsFallback {
+		return ""
+	}
+	if options.Data != nil {
+		return ExecuteTemplate(translateKey, options.Data)
+	}
+	return translateKey
+}
+
 // T is a shortcut for Translate
 func (t *I18n) T(
 	languageName string,
@@ -333,6 +654,8 @@ func (t *I18n) T(
 //
 // - "count" "100": Count for pluralization (optional).
 //
+// - "context" "verb": Context of the translation (optional).
+//
 // - Additional key-value pairs will be added to the Data map.
 //
 // Arguments are passed in pairs. The first item in each pair is the key, and the second is the value.
@@ -343,54 +666,64 @@ func (t *I18n) T(
 //
 // - For example, in "lang" "en", "lang" is the key and "en" is the value.
 //
-// As you can imagine, "lang", "key", "gender", and "count" are reserved keys.
+// As you can imagine, "lang", "key", "gender", "count" and "context" are reserved keys.
 // You can use any other key you want to pass data to translation.
 //
 // Note: All arguments are strings. The function will attempt to convert "count" to an integer.
 func (t *I18n) NewTemplatingTranslateFunc() func(args ...interface{}) string {
 	return func(args ...interface{}) string {
-		var lang, key string
-		var gender *string
-		var count *int
-		data := make(Data)
-
-		for i := 0; i < len(args); i += 2 {
-			if i+1 >= len(args) {
-				break
-			}
+		lang, key, options := templatingTranslateArgs(args)
+		return t.Translate(lang, key, options)
+	}
+}
 
-			keyStr, ok1 := args[i].(string)
-			valueStr, ok2 := args[i+1].(string)
+// templatingTranslateArgs reads the key-value arguments
+// of the functions created by NewTemplatingTranslateFunc
+func templatingTranslateArgs(args []interface{}) (string, string, Options) {
+	var lang, key, context string
+	var gender *string
+	var count *int
+	data := make(Data)
 
-			if !ok1 || !ok2 {
-				continue
-			}
+	for i := 0; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			break
+		}
 
-			switch keyStr {
-			case "lang":
-				lang = valueStr
-			case "key":
-				key = valueStr
-			case "count":
-				intVal, err := strconv.Atoi(valueStr)
-				if err == nil {
-					count = &intVal
-				}
-			case "gender":
-				gender = &valueStr
-			default:
-				data[keyStr] = valueStr
-			}
+		keyStr, ok1 := args[i].(string)
+		valueStr, ok2 := args[i+1].(string)
+
+		if !ok1 || !ok2 {
+			continue
 		}
 
-		options := Options{
-			Count:  count,
-			Gender: gender,
-			Data:   data,
+		switch keyStr {
+		case "lang":
+			lang = valueStr
+		case "key":
+			key = valueStr
+		case "count":
+			intVal, err := strconv.Atoi(valueStr)
+			if err == nil {
+				count = &intVal
+			}
+		case "gender":
+			gender = &valueStr
+		case "context":
+			context = valueStr
+		default:
+			data[keyStr] = valueStr
 		}
+	}
 
-		return t.Translate(lang, key, options)
+	options := Options{
+		Count:   count,
+		Gender:  gender,
+		Context: context,
+		Data:    data,
 	}
+
+	return lang, key, options
 }
 
 // NewLangTranslateFunc creates a function to translate a string in a specific language
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -103,6 +104,32 @@ func TestTranslate(t *testing.T) {
 		}
 	})
 
+	t.Run("method LanguageNames should return the sorted language names", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("es", TranslateStrings{})
+		i18n.AddLanguage("en", TranslateStrings{})
+
+		names := i18n.LanguageNames()
+		if len(names) != 2 || names[0] != "en" || names[1] != "es" {
+			t.Errorf("expected [en es]; got %v", names)
+		}
+	})
+
+	t.Run("method Translations should return a copy of the translations", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{{Key: "hello", Default: "Hello"}})
+
+		translations := i18n.Translations("en")
+		translations[0].Default = "Changed"
+
+		if !reflect.DeepEqual(i18n.Translations("en"), TranslateStrings{{Key: "hello", Default: "Hello"}}) {
+			t.Errorf("Unexpected result: %v", i18n.Translations("en"))
+		}
+		if i18n.Translations("xx") != nil {
+			t.Errorf("expected nil for a missing language")
+		}
+	})
+
 	t.Run("english should be the default fallback language even if multiple langs are added", func(t *testing.T) {
 		i18n := NewI18n()
 
@@ -639,6 +666,93 @@ func TestTranslate(t *testing.T) {
 		}
 	})
 
+	t.Run("the context should be used to pick the translation", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "open", Default: "Open"},
+			{Key: "open", Context: "status", Default: "Open"},
+			{Key: "english_only", Context: "status", Default: "English only"},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "open", Default: "Abrir"},
+			{Key: "open", Context: "status", Default: "Abierto"},
+		})
+
+		tests := []struct {
+			lang     string
+			key      string
+			options  Options
+			expected string
+		}{
+			{"es", "open", Options{}, "Abrir"},
+			{"es", "open", Options{Context: "status"}, "Abierto"},
+			{"es", "open", Options{Context: "xxx"}, ""},
+			{"es", "english_only", Options{Context: "status"}, "English only"},
+			{"es", "english_only", Options{}, ""},
+		}
+
+		for _, test := range tests {
+			got := i18n.Translate(test.lang, test.key, test.options)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+
+		got := execI18nTemplate(i18n.NewTemplatingTranslateFunc(), `{{ Translate "lang" "es" "key" "open" "context" "status" }}`)
+		if got != "Abierto" {
+			t.Errorf("expected Abierto; got %s", got)
+		}
+	})
+
+	t.Run("the context should be part of the consistency check", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{{Key: "open", Context: "status", Default: "Open"}})
+		errors := i18n.AddLanguage("es", TranslateStrings{{Key: "open", Default: "Abrir"}})
+
+		expected := []string{
+			"goeasyi18n: the language 'es' has the key 'open' that doesn't exist in 'en'",
+			"goeasyi18n: the language 'en' has the key 'open' with the context 'status' that doesn't exist in 'es'",
+		}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Unexpected result: %v", errors)
+		}
+	})
+
+	t.Run("the key should be the fallback with KeyAsFallback", func(t *testing.T) {
+		i18n := NewI18n(Config{KeyAsFallback: true})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "You have no new messages", Default: "No tienes mensajes nuevos"},
+		})
+
+		tests := []struct {
+			lang     string
+			key      string
+			options  Options
+			expected string
+		}{
+			{"es", "You have no new messages", Options{}, "No tienes mensajes nuevos"},
+			{"es", "Hello {{.Name}}", Options{}, "Hello {{.Name}}"},
+			{"es", "Hello {{.Name}}", Options{Data: Data{"Name": "John"}}, "Hello John"},
+			{"es", "You have no new messages", Options{Context: "xxx"}, "You have no new messages"},
+			{"xx", "Bye", Options{}, "Bye"},
+		}
+
+		for _, test := range tests {
+			got := i18n.Translate(test.lang, test.key, test.options)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+
+		empty := NewI18n(Config{KeyAsFallback: true})
+		if got := empty.T("en", "Hello"); got != "Hello" {
+			t.Errorf("expected Hello; got %s", got)
+		}
+		if got := NewI18n().T("en", "Hello"); got != "" {
+			t.Errorf("expected an empty string; got %s", got)
+		}
+	})
+
 	t.Run("test inconcistencies at the moment of adding a new language", func(t *testing.T) {
 		i18n := NewI18n()
 
@@ -721,6 +835,120 @@ func TestTranslate(t *testing.T) {
 	})
 }
 
+func TestRuntimeChanges(t *testing.T) {
+	customPluralizationFunc := func(count int) string {
+		if count == 0 {
+			return "Zero"
+		}
+		return DefaultPluralizationFunc(count)
+	}
+
+	newI18n := func() *I18n {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "title", Default: "Projects"},
+			{Key: "items", Zero: "No items", One: "One item", Many: "Many items"},
+		})
+		i18n.SetPluralizationFunc("en", customPluralizationFunc)
+		return i18n
+	}
+
+	t.Run("MergeLanguage should override and add keys", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.MergeLanguage("en", TranslateStrings{
+			{Key: "title", Default: "Matters"},
+			{Key: "billing", Default: "Billing"},
+		})
+
+		tests := []struct {
+			key      string
+			options  Options
+			expected string
+		}{
+			{"title", Options{}, "Matters"},
+			{"billing", Options{}, "Billing"},
+			{"items", Options{Count: createPtr(0)}, "No items"},
+			{"items", Options{Count: createPtr(2)}, "Many items"},
+		}
+
+		for _, test := range tests {
+			got := i18n.Translate("en", test.key, test.options)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+	})
+
+	t.Run("MergeLanguage should not modify the added slice", func(t *testing.T) {
+		i18n := NewI18n()
+		base := TranslateStrings{{Key: "title", Default: "Projects"}}
+		i18n.AddLanguage("en", base)
+		i18n.MergeLanguage("en", TranslateStrings{{Key: "title", Default: "Matters"}})
+
+		if base[0].Default != "Projects" {
+			t.Errorf("Unexpected result: %v", base)
+		}
+	})
+
+	t.Run("MergeLanguage should create the language and check the consistency", func(t *testing.T) {
+		i18n := newI18n()
+		errors := i18n.MergeLanguage("es", TranslateStrings{{Key: "title", Default: "Proyectos"}})
+
+		if len(errors) != 1 || !strings.Contains(errors[0], "items") {
+			t.Errorf("Unexpected result: %v", errors)
+		}
+		if got := i18n.Translate("es", "title"); got != "Proyectos" {
+			t.Errorf("expected Proyectos; got %s", got)
+		}
+		if got := i18n.Translate("es", "items", Options{Count: createPtr(1)}); got != "One item" {
+			t.Errorf("expected One item; got %s", got)
+		}
+	})
+
+	t.Run("SetTranslation and RemoveTranslation should change a single key", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.SetTranslation("en", TranslateString{Key: "title", Default: "Matters"})
+		i18n.SetTranslation("en", TranslateString{Key: "billing", Default: "Billing"})
+
+		if got := i18n.Translate("en", "title"); got != "Matters" {
+			t.Errorf("expected Matters; got %s", got)
+		}
+		if got := i18n.Translate("en", "billing"); got != "Billing" {
+			t.Errorf("expected Billing; got %s", got)
+		}
+
+		if !i18n.RemoveTranslation("en", "billing") {
+			t.Errorf("expected billing to be removed")
+		}
+		i18n.SetTranslation("en", TranslateString{Key: "title", Context: "menu", Default: "Menu"})
+		if !i18n.RemoveTranslation("en", "title", "menu") || i18n.Translate("en", "title") != "Matters" {
+			t.Errorf("expected only the title with context to be removed")
+		}
+		if i18n.RemoveTranslation("en", "billing") || i18n.RemoveTranslation("xx", "title") {
+			t.Errorf("expected nothing to be removed")
+		}
+		if got := i18n.Translate("en", "billing"); got != "" {
+			t.Errorf("expected an empty string; got %s", got)
+		}
+		if got := i18n.Translate("en", "items", Options{Count: createPtr(0)}); got != "No items" {
+			t.Errorf("expected No items; got %s", got)
+		}
+	})
+
+	t.Run("RemoveLanguage should remove the language", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.AddLanguage("es", TranslateStrings{{Key: "title", Default: "Proyectos"}})
+		i18n.RemoveLanguage("es")
+
+		if i18n.HasLanguage("es") {
+			t.Errorf("expected es to be removed")
+		}
+		if got := i18n.Translate("es", "title"); got != "Projects" {
+			t.Errorf("expected the fallback language; got %s", got)
+		}
+	})
+}
+
 // Function to create pointer to a value
 func createPtr[T string | int](s T) *T {
 	return &s
@@ -0,0 +1,569 @@
+package goeasyi18n
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template/parse"
+	"unicode"
+)
+
+// icuPluralCategories are the ICU plural categories of the
+// plural variants, Many is the "other" category because it is
+// the fallback of DefaultPluralizationFunc
+var icuPluralCategories = []struct {
+	category string
+	variant  string
+}{
+	{"zero", "Zero"},
+	{"one", "One"},
+	{"two", "Two"},
+	{"few", "Few"},
+	{"other", "Many"},
+}
+
+// icuGenders are the ICU select cases of the gender variants
+var icuGenders = []struct {
+	selector string
+	variant  string
+}{
+	{"male", "Male"},
+	{"female", "Female"},
+	{"nonbinary", "NonBinary"},
+}
+
+// icuCountArgument is the name of the plural argument
+// of the exported messages
+const icuCountArgument = "count"
+
+// icuNode is a part of an ICU message: a literal text, an
+// argument ("#" in plurals) or a plural or select argument
+type icuNode struct {
+	text       string
+	argument   string
+	selectType string
+	cases      []icuCase
+}
+
+type icuCase struct {
+	selector string
+	message  []icuNode
+}
+
+// icuToTranslateString converts an ICU message to the variants
+// of a translation. The plural and gender (select) arguments
+// become the variants and the text around them is copied to all
+// of them, the placeholders become template fields:
+//
+//	{count, plural, one {# email} other {# emails}} for {name}
+//
+// is One "{{.count}} email for {{.name}}" and Many
+// "{{.count}} emails for {{.name}}"
+func icuToTranslateString(message string) (TranslateString, error) {
+	parser := &icuParser{src: []rune(message)}
+	nodes, err := parser.parseMessage(false)
+	if err != nil {
+		return TranslateString{}, err
+	}
+	if parser.pos < len(parser.src) {
+		return TranslateString{}, fmt.Errorf("icu: unexpected '}' at %d", parser.pos)
+	}
+
+	ts := TranslateString{}
+	if err := setIcuVariants(&ts, nodes, ""); err != nil {
+		return TranslateString{}, err
+	}
+	return ts, nil
+}
+
+// setIcuVariants sets the variants of the message, gender is the
+// suffix of the variants inside a gender select
+func setIcuVariants(ts *TranslateString, nodes []icuNode, gender string) error {
+	complexIndex := -1
+	for i, node := range nodes {
+		if node.selectType == "" {
+			continue
+		}
+		if complexIndex >= 0 {
+			return fmt.Errorf("icu: a message can only have one plural or select argument")
+		}
+		complexIndex = i
+	}
+
+	value := reflect.ValueOf(ts).Elem()
+	if complexIndex < 0 {
+		text, err := icuToTemplate(nodes, "")
+		if err != nil {
+			return err
+		}
+		variant := gender
+		if variant == "" {
+			variant = "Default"
+		}
+		value.FieldByName(variant).SetString(text)
+		return nil
+	}
+
+	complexNode := nodes[complexIndex]
+	prefix, suffix := nodes[:complexIndex], nodes[complexIndex+1:]
+	caseNodes := func(c icuCase) []icuNode {
+		joined := append([]icuNode{}, prefix...)
+		joined = append(joined, c.message...)
+		return append(joined, suffix...)
+	}
+
+	switch complexNode.selectType {
+	case "select":
+		if gender != "" {
+			return fmt.Errorf("icu: a gender select can't be inside another one")
+		}
+		for _, c := range complexNode.cases {
+			caseGender, found := "", c.selector == "other"
+			for _, g := range icuGenders {
+				if strings.EqualFold(c.selector, g.selector) || c.selector == "non-binary" && g.variant == "NonBinary" {
+					caseGender, found = g.variant, true
+				}
+			}
+			if !found {
+				return fmt.Errorf("icu: the select case '%s' is not a gender (male, female, nonbinary or other)", c.selector)
+			}
+			if err := setIcuVariants(ts, caseNodes(c), caseGender); err != nil {
+				return err
+			}
+		}
+	case "plural":
+		hasOther := false
+		for _, c := range complexNode.cases {
+			hasOther = hasOther || c.selector == "other"
+		}
+		for _, c := range complexNode.cases {
+			var variant string
+			switch c.selector {
+			case "zero", "=0":
+				variant = "Zero"
+			case "one", "=1":
+				variant = "One"
+			case "two", "=2":
+				variant = "Two"
+			case "few":
+				variant = "Few"
+			case "many":
+				// Many is also the "other" category
+				if hasOther {
+					continue
+				}
+				variant = "Many"
+			case "other":
+				variant = "Many"
+			default:
+				return fmt.Errorf("icu: the plural case '%s' is not supported", c.selector)
+			}
+
+			text, err := icuToTemplate(caseNodes(c), complexNode.argument)
+			if err != nil {
+				return err
+			}
+			value.FieldByName(variant + gender).SetString(text)
+		}
+	default:
+		return fmt.Errorf("icu: the argument type '%s' is not supported", complexNode.selectType)
+	}
+
+	return nil
+}
+
+// icuToTemplate converts the texts and the placeholders of a message
+// to a template, countArgument is the argument of the "#" placeholders
+func icuToTemplate(nodes []icuNode, countArgument string) (string, error) {
+	var sb strings.Builder
+	for _, node := range nodes {
+		switch {
+		case node.selectType != "":
+			return "", fmt.Errorf("icu: the plural and select arguments can't be nested")
+		case node.argument == "#":
+			sb.WriteString(templateField(countArgument))
+		case node.argument != "":
+			sb.WriteString(templateField(node.argument))
+		default:
+			// The delimiters of the templates are written as texts
+			sb.WriteString(strings.ReplaceAll(node.text, "{{", `{{"{{"}}`))
+		}
+	}
+	return sb.String(), nil
+}
+
+// templateField is the template action that prints a field of the data,
+// the names that aren't identifiers (like "0") use the index function
+func templateField(name string) string {
+	isIdentifier := name != ""
+	for i, r := range name {
+		if !(r == '_' || unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r)) {
+			isIdentifier = false
+		}
+	}
+	if isIdentifier {
+		return "{{." + name + "}}"
+	}
+	return "{{index . " + strconv.Quote(name) + "}}"
+}
+
+// icuParser parses the ICU messages (MessageFormat) used by
+// ARB files, the plural offsets and selectordinal aren't supported
+type icuParser struct {
+	src []rune
+	pos int
+}
+
+// parseMessage parses until the end or an unmatched "}"
+func (p *icuParser) parseMessage(inPlural bool) ([]icuNode, error) {
+	var nodes []icuNode
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, icuNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '\'':
+			p.pos++
+			if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+				text.WriteRune('\'')
+				p.pos++
+				continue
+			}
+			if p.pos >= len(p.src) || !strings.ContainsRune("{}#|", p.src[p.pos]) {
+				text.WriteRune('\'')
+				continue
+			}
+			// Quoted literal text until the next single apostrophe
+			for p.pos < len(p.src) {
+				if p.src[p.pos] == '\'' {
+					if p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'' {
+						text.WriteRune('\'')
+						p.pos += 2
+						continue
+					}
+					p.pos++
+					break
+				}
+				text.WriteRune(p.src[p.pos])
+				p.pos++
+			}
+		case r == '{':
+			flushText()
+			node, err := p.parseArgument()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		case r == '}':
+			flushText()
+			return nodes, nil
+		case r == '#' && inPlural:
+			flushText()
+			nodes = append(nodes, icuNode{argument: "#"})
+			p.pos++
+		default:
+			text.WriteRune(r)
+			p.pos++
+		}
+	}
+
+	flushText()
+	return nodes, nil
+}
+
+// parseArgument parses an argument like {name}, {name, number}
+// or {name, plural, one {...} other {...}}
+func (p *icuParser) parseArgument() (icuNode, error) {
+	start := p.pos
+	p.pos++
+
+	name := p.readUntil(",}")
+	if name == "" {
+		return icuNode{}, fmt.Errorf("icu: the argument at %d doesn't have a name", start)
+	}
+	if p.pos >= len(p.src) {
+		return icuNode{}, fmt.Errorf("icu: the argument '%s' is not closed", name)
+	}
+	if p.src[p.pos] == '}' {
+		p.pos++
+		return icuNode{argument: name}, nil
+	}
+
+	p.pos++
+	argumentType := p.readUntil(",}")
+	if argumentType != "plural" && argumentType != "select" {
+		if argumentType == "selectordinal" {
+			return icuNode{}, fmt.Errorf("icu: the argument type 'selectordinal' is not supported")
+		}
+		// The formats like {price, number, currency} are plain placeholders
+		if err := p.skipArgument(); err != nil {
+			return icuNode{}, err
+		}
+		return icuNode{argument: name}, nil
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != ',' {
+		return icuNode{}, fmt.Errorf("icu: the argument '%s' doesn't have cases", name)
+	}
+	p.pos++
+
+	node := icuNode{argument: name, selectType: argumentType}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.src) {
+			return icuNode{}, fmt.Errorf("icu: the argument '%s' is not closed", name)
+		}
+		if p.src[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		selector := p.readUntil("{ \t\n\r")
+		if strings.HasPrefix(selector, "offset:") {
+			return icuNode{}, fmt.Errorf("icu: the plural offsets are not supported")
+		}
+		p.skipSpaces()
+		if selector == "" || p.pos >= len(p.src) || p.src[p.pos] != '{' {
+			return icuNode{}, fmt.Errorf("icu: invalid case in the argument '%s'", name)
+		}
+		p.pos++
+
+		message, err := p.parseMessage(argumentType == "plural")
+		if err != nil {
+			return icuNode{}, err
+		}
+		if p.pos >= len(p.src) {
+			return icuNode{}, fmt.Errorf("icu: the case '%s' of the argument '%s' is not closed", selector, name)
+		}
+		p.pos++
+		node.cases = append(node.cases, icuCase{selector: selector, message: message})
+	}
+
+	return node, nil
+}
+
+// readUntil reads until one of the characters and trims the spaces
+func (p *icuParser) readUntil(chars string) string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(chars, p.src[p.pos]) {
+		p.pos++
+	}
+	return strings.TrimSpace(string(p.src[start:p.pos]))
+}
+
+func (p *icuParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// skipArgument skips the rest of an argument, including its closing "}"
+func (p *icuParser) skipArgument() error {
+	depth := 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+			depth--
+		}
+	}
+	return fmt.Errorf("icu: an argument is not closed")
+}
+
+// translateStringToIcu converts the variants of a translation to an ICU
+// message with plural and gender (select) arguments and returns the
+// names of its placeholders. Only the templates that print fields
+// (like {{.Name}}) can be converted.
+//
+// The Default text is the "other" case of the plural when there
+// is no Many variant.
+func translateStringToIcu(ts TranslateString) (string, []string, error) {
+	value := reflect.ValueOf(ts)
+	field := func(name string) string {
+		return value.FieldByName(name).String()
+	}
+	placeholders := []string{}
+	addPlaceholders := func(names []string) {
+		for _, name := range names {
+			if !containsString(placeholders, name) {
+				placeholders = append(placeholders, name)
+			}
+		}
+	}
+
+	message := func(gender string) (string, error) {
+		hasPlural := false
+		for _, category := range icuPluralCategories {
+			hasPlural = hasPlural || field(category.variant+gender) != ""
+		}
+
+		base := ts.Default
+		if gender != "" {
+			base = field(gender)
+		}
+		if !hasPlural {
+			text, names, err := templateToIcu(base, false)
+			addPlaceholders(names)
+			return text, err
+		}
+
+		addPlaceholders([]string{icuCountArgument})
+		var sb strings.Builder
+		sb.WriteString("{" + icuCountArgument + ", plural,")
+		for _, category := range icuPluralCategories {
+			text := field(category.variant + gender)
+			if category.category == "other" && text == "" {
+				text = base
+			}
+			if text == "" && category.category != "other" {
+				continue
+			}
+			converted, names, err := templateToIcu(text, true)
+			if err != nil {
+				return "", err
+			}
+			addPlaceholders(names)
+			sb.WriteString(" " + category.category + " {" + converted + "}")
+		}
+		sb.WriteString("}")
+		return sb.String(), nil
+	}
+
+	other, err := message("")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	for _, g := range icuGenders {
+		hasGender := field(g.variant) != ""
+		for _, category := range icuPluralCategories {
+			hasGender = hasGender || field(category.variant+g.variant) != ""
+		}
+		if !hasGender {
+			continue
+		}
+
+		text, err := message(g.variant)
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(" " + g.selector + " {" + text + "}")
+	}
+	if sb.Len() == 0 {
+		return other, placeholders, nil
+	}
+	addPlaceholders([]string{"gender"})
+
+	return "{gender, select," + sb.String() + " other {" + other + "}}", placeholders, nil
+}
+
+// templateToIcu converts a template that only prints fields to the
+// text of an ICU message, the "#" are escaped inside the plurals
+func templateToIcu(text string, inPlural bool) (string, []string, error) {
+	tree := parse.New("translation")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, "", "", map[string]*parse.Tree{}); err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	var names []string
+	if tree.Root == nil {
+		return "", nil, nil
+	}
+
+	// The texts are escaped together, the quotes of
+	// consecutive special characters can't be split
+	var literal strings.Builder
+	for _, node := range tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			literal.Write(n.Text)
+			continue
+		case *parse.ActionNode:
+			if name, ok := templateFieldName(n); ok {
+				sb.WriteString(escapeIcuText(literal.String(), inPlural))
+				literal.Reset()
+				sb.WriteString("{" + name + "}")
+				names = append(names, name)
+				continue
+			}
+			// Texts like {{"{{"}} that can't be written directly
+			if len(n.Pipe.Decl) == 0 && len(n.Pipe.Cmds) == 1 && len(n.Pipe.Cmds[0].Args) == 1 {
+				if str, ok := n.Pipe.Cmds[0].Args[0].(*parse.StringNode); ok {
+					literal.WriteString(str.Text)
+					continue
+				}
+			}
+		}
+		return "", nil, fmt.Errorf("icu: the template action %s can't be converted to an ICU message", node)
+	}
+	sb.WriteString(escapeIcuText(literal.String(), inPlural))
+
+	return sb.String(), names, nil
+}
+
+// templateFieldName returns the field printed by actions like
+// {{.Name}} or {{index . "name"}}
+func templateFieldName(action *parse.ActionNode) (string, bool) {
+	if len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 {
+		return "", false
+	}
+	args := action.Pipe.Cmds[0].Args
+
+	if len(args) == 1 {
+		if field, ok := args[0].(*parse.FieldNode); ok && len(field.Ident) == 1 {
+			return field.Ident[0], true
+		}
+	}
+	if len(args) == 3 {
+		identifier, isIdentifier := args[0].(*parse.IdentifierNode)
+		_, isDot := args[1].(*parse.DotNode)
+		name, isString := args[2].(*parse.StringNode)
+		if isIdentifier && identifier.Ident == "index" && isDot && isString {
+			return name.Text, true
+		}
+	}
+	return "", false
+}
+
+// escapeIcuText quotes the special characters of the ICU messages,
+// the consecutive special characters share the quotes
+func escapeIcuText(text string, inPlural bool) string {
+	var sb strings.Builder
+	inQuote := false
+	for _, r := range text {
+		switch {
+		case r == '\'':
+			sb.WriteString("''")
+		case r == '{' || r == '}' || r == '#' && inPlural:
+			if !inQuote {
+				sb.WriteRune('\'')
+				inQuote = true
+			}
+			sb.WriteRune(r)
+		default:
+			if inQuote {
+				sb.WriteRune('\'')
+				inQuote = false
+			}
+			sb.WriteRune(r)
+		}
+	}
+	if inQuote {
+		sb.WriteRune('\'')
+	}
+	return sb.String()
+}
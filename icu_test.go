@@ -0,0 +1,121 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIcuToTranslateString(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected TranslateString
+	}{
+		{
+			name:     "text with placeholders",
+			message:  "Hello {name}, you have {total, number} points",
+			expected: TranslateString{Default: "Hello {{.name}}, you have {{.total}} points"},
+		},
+		{
+			name:     "quoted texts",
+			message:  "It''s '{literal}' and '{{'no'}}' isn't a placeholder",
+			expected: TranslateString{Default: "It's {literal} and {{\"{{\"}}no}} isn't a placeholder"},
+		},
+		{
+			name:    "plural with the text around it",
+			message: "You have {count, plural, =0 {no emails} one {# email} other {# emails}} from {0}",
+			expected: TranslateString{
+				Zero: "You have no emails from {{index . \"0\"}}",
+				One:  "You have {{.count}} email from {{index . \"0\"}}",
+				Many: "You have {{.count}} emails from {{index . \"0\"}}",
+			},
+		},
+		{
+			name:    "gender select with plurals",
+			message: "{gender, select, male {{n, plural, one {He has # cat} other {He has # cats}}} female {She has cats} other {They have cats}}",
+			expected: TranslateString{
+				Default:  "They have cats",
+				Female:   "She has cats",
+				OneMale:  "He has {{.n}} cat",
+				ManyMale: "He has {{.n}} cats",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts, err := icuToTranslateString(test.message)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(ts, test.expected) {
+				t.Errorf("expected %#v; got %#v", test.expected, ts)
+			}
+		})
+	}
+
+	t.Run("handle messages that can't be converted", func(t *testing.T) {
+		messages := []string{
+			"Hello {name",
+			"Hello }",
+			"{a, plural, one {x} other {y}} {b, plural, one {x} other {y}}",
+			"{a, plural, offset:1 one {x} other {y}}",
+			"{a, selectordinal, one {#st} other {#th}}",
+			"{a, select, cat {x} other {y}}",
+		}
+		for _, message := range messages {
+			if _, err := icuToTranslateString(message); err == nil {
+				t.Errorf("Expected error for %s, got nil", message)
+			}
+		}
+	})
+}
+
+func TestTranslateStringToIcu(t *testing.T) {
+	t.Run("convert the variants to plural and select arguments", func(t *testing.T) {
+		message, placeholders, err := translateStringToIcu(TranslateString{
+			Default:    "{{.Name}} has cats",
+			One:        "{{.Name}} has one cat",
+			Many:       "{{.Name}} has {{.Qty}} cats #1",
+			Female:     "She has cats",
+			OneFemale:  "She has one cat",
+			ManyFemale: "She has cats {ok}",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "{gender, select, female {{count, plural, one {She has one cat} other {She has cats '{'ok'}'}}} other {{count, plural, one {{Name} has one cat} other {{Name} has {Qty} cats '#'1}}}}"
+		if message != expected {
+			t.Errorf("expected %s; got %s", expected, message)
+		}
+		if !reflect.DeepEqual(placeholders, []string{"count", "Name", "Qty", "gender"}) {
+			t.Errorf("Unexpected result: %v", placeholders)
+		}
+	})
+
+	t.Run("converted messages can be converted back", func(t *testing.T) {
+		original := TranslateString{
+			Default: "It's {{\"{{\"}}{}",
+			Male:    "{{index . \"0\"}} sir",
+		}
+		message, _, err := translateStringToIcu(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		converted, err := icuToTranslateString(message)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(converted, original) {
+			t.Errorf("expected %#v; got %#v", original, converted)
+		}
+	})
+
+	t.Run("handle templates that can't be converted", func(t *testing.T) {
+		_, _, err := translateStringToIcu(TranslateString{Default: "{{if .Admin}}Admin{{end}}"})
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,580 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// catalogDecoder decodes the translations of a single language and
+// returns the position where every translation starts, in strict mode
+// the unknown fields are rejected
+type catalogDecoder func(data []byte, strict bool) (TranslateStrings, []Position, error)
+
+// Position is a location in a file, File is empty for the
+// translations loaded from bytes or strings and Line and
+// Column start at 1 (0 means unknown)
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	var location string
+	switch {
+	case p.Line > 0 && p.Column > 0 && p.File != "":
+		location = fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	case p.Line > 0 && p.File != "":
+		location = fmt.Sprintf("%s:%d", p.File, p.Line)
+	case p.Line > 0 && p.Column > 0:
+		location = fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+	case p.Line > 0:
+		location = fmt.Sprintf("line %d", p.Line)
+	default:
+		location = p.File
+	}
+	return location
+}
+
+// LoadError is returned by the loaders when a file can't be
+// loaded, it has the position of the error and the key of
+// the translation when they are known
+type LoadError struct {
+	Position
+	Key string
+	Err error
+}
+
+func (e *LoadError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("goeasyi18n: ")
+	if location := e.Position.String(); location != "" {
+		sb.WriteString(location)
+		sb.WriteString(": ")
+	}
+	if e.Key != "" {
+		sb.WriteString("key '")
+		sb.WriteString(e.Key)
+		sb.WriteString("': ")
+	}
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// withFile adds the file to the position of a load error,
+// other errors are wrapped in a load error
+func withFile(err error, file string) error {
+	var loadError *LoadError
+	if errors.As(err, &loadError) {
+		loadError.File = file
+		return loadError
+	}
+	return &LoadError{Position: Position{File: file}, Err: err}
+}
+
+// positionAt returns the line and column
+// of the byte at the offset
+func positionAt(data []byte, offset int) Position {
+	return newPositionTracker(data).at(offset)
+}
+
+// positionTracker returns the positions of increasing offsets
+// without scanning the data from the start for every offset
+type positionTracker struct {
+	data     []byte
+	offset   int
+	position Position
+}
+
+func newPositionTracker(data []byte) *positionTracker {
+	return &positionTracker{
+		data:     data,
+		position: Position{Line: 1, Column: 1},
+	}
+}
+
+// at returns the line and column of the byte at the offset,
+// it starts again from the beginning if the offset goes back
+func (pt *positionTracker) at(offset int) Position {
+	if offset > len(pt.data) {
+		offset = len(pt.data)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset < pt.offset {
+		pt.offset = 0
+		pt.position = Position{Line: 1, Column: 1}
+	}
+
+	skipped := pt.data[pt.offset:offset]
+	if lines := bytes.Count(skipped, []byte("\n")); lines > 0 {
+		lineStart := bytes.LastIndexByte(skipped, '\n') + 1
+		pt.position.Line += lines
+		pt.position.Column = utf8.RuneCount(skipped[lineStart:]) + 1
+	} else {
+		pt.position.Column += utf8.RuneCount(skipped)
+	}
+	pt.offset = offset
+
+	return pt.position
+}
+
+// isTranslateStringField checks if the name is a field of TranslateString,
+// the check is case insensitive like the JSON decoding
+func isTranslateStringField(name string) bool {
+	translateStringType := reflect.TypeOf(TranslateString{})
+	for i := 0; i < translateStringType.NumField(); i++ {
+		field := translateStringType.Field(i)
+		fieldName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+		if strings.EqualFold(fieldName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// setTranslateStringField sets a field of the TranslateString from
+// its text representation, used by the loaders that only have text
+// (the Tags are comma separated, MaxLength is a number and
+// Deprecated is a boolean)
+func setTranslateStringField(ts *TranslateString, name string, value string) error {
+	field := reflect.ValueOf(ts).Elem().FieldByName(name)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("the field '%s' must be a number", name)
+		}
+		field.SetInt(int64(number))
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("the field '%s' must be true or false", name)
+		}
+		field.SetBool(boolean)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return unknownFieldError(name)
+	}
+	return nil
+}
+
+// errUnknownField is wrapped by the errors of the strict mode
+var errUnknownField = errors.New("unknown field")
+
+// unknownFieldError is the error of the strict mode
+// for the fields that are not part of TranslateString
+func unknownFieldError(name string) error {
+	return fmt.Errorf("%w '%s'", errUnknownField, name)
+}
+
+// LoadErrors are the problems found by LoadDir and LoadFS with the
+// CollectLoadErrors config, the catalogs were added despite them
+type LoadErrors struct {
+	Errors []error
+}
+
+func (e *LoadErrors) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// DuplicateKeyPolicy defines what the loaders do when the
+// same key is defined more than once
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeyPolicyError makes the loaders fail with a
+	// *DuplicateKeysError (default of the config and LoadOptions)
+	DuplicateKeyPolicyError DuplicateKeyPolicy = iota
+	// DuplicateKeyPolicyFirstWins keeps the first definition
+	DuplicateKeyPolicyFirstWins
+	// DuplicateKeyPolicyLastWins keeps the last definition
+	DuplicateKeyPolicyLastWins
+	// DuplicateKeyPolicyKeepAll keeps all the definitions, Translate
+	// uses the first one (default of the loaders without options)
+	DuplicateKeyPolicyKeepAll
+)
+
+// LoadOptions are the options of the loaders that end with
+// WithOptions, like LoadFromJsonFilesWithOptions
+type LoadOptions struct {
+	// Default: DuplicateKeyPolicyError
+	DuplicateKeyPolicy DuplicateKeyPolicy
+	// Rejects the unknown fields of the translations, like a
+	// misspelled "Mnay". The columns of the CSV files are languages
+	// and XLIFF has a fixed schema, so it doesn't apply to them.
+	// Default: false
+	Strict bool
+}
+
+// defaultLoadOptions are the options of the loaders without
+// options (like LoadFromJsonFiles), they keep all the
+// definitions of the keys that are defined more than once
+var defaultLoadOptions = LoadOptions{DuplicateKeyPolicy: DuplicateKeyPolicyKeepAll}
+
+// DuplicateKey is a key that is defined more than once
+// with the positions of all its definitions, the language
+// is set by the loaders of multiple languages
+type DuplicateKey struct {
+	Language  string
+	Key       string
+	Context   string
+	Positions []Position
+}
+
+// DuplicateKeysError is returned by the loaders when the same
+// key is defined more than once (in the same file or in
+// multiple files) and the policy is DuplicateKeyPolicyError
+type DuplicateKeysError struct {
+	Duplicates []DuplicateKey
+}
+
+func (e *DuplicateKeysError) Error() string {
+	messages := make([]string, 0, len(e.Duplicates))
+	for _, duplicate := range e.Duplicates {
+		positions := make([]string, 0, len(duplicate.Positions))
+		for _, position := range duplicate.Positions {
+			positions = append(positions, position.String())
+		}
+		language := ""
+		if duplicate.Language != "" {
+			language = fmt.Sprintf(" in the language '%s'", duplicate.Language)
+		}
+		messages = append(messages, fmt.Sprintf(
+			"goeasyi18n: the key %s is defined more than once%s (%s)",
+			describeKey(duplicate.Key, duplicate.Context),
+			language,
+			strings.Join(positions, "; "),
+		))
+	}
+	return strings.Join(messages, "\n")
+}
+
+// resolveDuplicateKeys applies the policy to the keys that are defined
+// more than once, the positions must have the same length as the
+// translations
+func resolveDuplicateKeys(
+	translateStrings TranslateStrings,
+	positions []Position,
+	policy DuplicateKeyPolicy,
+) (TranslateStrings, error) {
+	if policy == DuplicateKeyPolicyKeepAll {
+		return translateStrings, nil
+	}
+
+	resolved := make(TranslateStrings, 0, len(translateStrings))
+	resolvedIndexes := make(map[string]int, len(translateStrings))
+	firstIndexes := make(map[string]int, len(translateStrings))
+	duplicateIndexes := map[string]int{}
+	var duplicates []DuplicateKey
+
+	for i, ts := range translateStrings {
+		id := translationID(ts.Key, ts.Context)
+		index, exists := resolvedIndexes[id]
+		if !exists {
+			resolvedIndexes[id] = len(resolved)
+			firstIndexes[id] = i
+			resolved = append(resolved, ts)
+			continue
+		}
+
+		switch policy {
+		case DuplicateKeyPolicyFirstWins:
+			continue
+		case DuplicateKeyPolicyLastWins:
+			resolved[index] = ts
+			continue
+		}
+
+		duplicateIndex, reported := duplicateIndexes[id]
+		if !reported {
+			duplicateIndex = len(duplicates)
+			duplicateIndexes[id] = duplicateIndex
+			duplicates = append(duplicates, DuplicateKey{
+				Key:       ts.Key,
+				Context:   ts.Context,
+				Positions: []Position{positions[firstIndexes[id]]},
+			})
+		}
+		duplicates[duplicateIndex].Positions = append(
+			duplicates[duplicateIndex].Positions,
+			positions[i],
+		)
+	}
+
+	if len(duplicates) > 0 {
+		return nil, &DuplicateKeysError{Duplicates: duplicates}
+	}
+
+	return resolved, nil
+}
+
+// positionsWithFile adds the file to the
+// positions of the translations of a file
+func positionsWithFile(positions []Position, file string) []Position {
+	withFile := make([]Position, len(positions))
+	for i, position := range positions {
+		position.File = file
+		withFile[i] = position
+	}
+	return withFile
+}
+
+// loadFromBytes decodes the translations of a single language
+// and applies the strict mode and the duplicate key policy of the options
+func loadFromBytes(
+	decode catalogDecoder,
+	data []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	translateStrings, positions, err := decode(data, options.Strict)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveDuplicateKeys(translateStrings, positions, options.DuplicateKeyPolicy)
+}
+
+// loadFromFiles decodes and merges the translations of one or multiple
+// files (allowing glob patterns) and applies the duplicate key policy
+func loadFromFiles(
+	decode catalogDecoder,
+	filesOrGlobs []string,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	var allTranslateStrings TranslateStrings
+	var allPositions []Position
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range matches {
+			byteValue, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			translateStrings, positions, err := decode(byteValue, options.Strict)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			allTranslateStrings = append(allTranslateStrings, translateStrings...)
+			allPositions = append(allPositions, positionsWithFile(positions, file)...)
+		}
+	}
+
+	return resolveDuplicateKeys(allTranslateStrings, allPositions, options.DuplicateKeyPolicy)
+}
+
+// loadFromFS decodes and merges the translations of one or multiple
+// files located within a provided filesystem (fs.FS), allowing glob
+// patterns, and applies the duplicate key policy
+func loadFromFS(
+	decode catalogDecoder,
+	fileSystem fs.FS,
+	filesOrGlobs []string,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	var allTranslateStrings TranslateStrings
+	var allPositions []Position
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := fs.Glob(fileSystem, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		for _, file := range matches {
+			byteValue, err := readFileFromFS(fileSystem, file)
+			if err != nil {
+				return nil, err
+			}
+
+			translateStrings, positions, err := decode(byteValue, options.Strict)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			allTranslateStrings = append(allTranslateStrings, translateStrings...)
+			allPositions = append(allPositions, positionsWithFile(positions, file)...)
+		}
+	}
+
+	return resolveDuplicateKeys(allTranslateStrings, allPositions, options.DuplicateKeyPolicy)
+}
+
+// multiCatalogDecoder decodes the translations of multiple languages
+// (like the CSV and XLIFF files) and the position of every translation
+type multiCatalogDecoder func(data []byte) (map[string]TranslateStrings, map[string][]Position, error)
+
+// loadMultipleFromBytes decodes the translations of multiple languages
+// and applies the duplicate key policy of the options
+func loadMultipleFromBytes(
+	decode multiCatalogDecoder,
+	data []byte,
+	options LoadOptions,
+) (map[string]TranslateStrings, error) {
+	translations, positions, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveMultipleDuplicateKeys(translations, positions, options.DuplicateKeyPolicy)
+}
+
+// loadMultipleFromFiles decodes and merges the translations of multiple
+// languages of one or multiple files (allowing glob patterns) and
+// applies the duplicate key policy of the options
+func loadMultipleFromFiles(
+	decode multiCatalogDecoder,
+	filesOrGlobs []string,
+	options LoadOptions,
+) (map[string]TranslateStrings, error) {
+	allTranslations := map[string]TranslateStrings{}
+	allPositions := map[string][]Position{}
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range matches {
+			byteValue, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			translations, positions, err := decode(byteValue)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			for language, translateStrings := range translations {
+				allTranslations[language] = append(allTranslations[language], translateStrings...)
+				allPositions[language] = append(allPositions[language], positionsWithFile(positions[language], file)...)
+			}
+		}
+	}
+
+	return resolveMultipleDuplicateKeys(allTranslations, allPositions, options.DuplicateKeyPolicy)
+}
+
+// loadMultipleFromFS decodes and merges the translations of multiple
+// languages of one or multiple files located within a provided
+// filesystem (fs.FS), allowing glob patterns, and applies the
+// duplicate key policy of the options
+func loadMultipleFromFS(
+	decode multiCatalogDecoder,
+	fileSystem fs.FS,
+	filesOrGlobs []string,
+	options LoadOptions,
+) (map[string]TranslateStrings, error) {
+	allTranslations := map[string]TranslateStrings{}
+	allPositions := map[string][]Position{}
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := fs.Glob(fileSystem, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range matches {
+			byteValue, err := readFileFromFS(fileSystem, file)
+			if err != nil {
+				return nil, err
+			}
+
+			translations, positions, err := decode(byteValue)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			for language, translateStrings := range translations {
+				allTranslations[language] = append(allTranslations[language], translateStrings...)
+				allPositions[language] = append(allPositions[language], positionsWithFile(positions[language], file)...)
+			}
+		}
+	}
+
+	return resolveMultipleDuplicateKeys(allTranslations, allPositions, options.DuplicateKeyPolicy)
+}
+
+// resolveMultipleDuplicateKeys applies the policy to the keys that
+// are defined more than once in every language, the duplicates of all
+// the languages are reported together (sorted by language)
+func resolveMultipleDuplicateKeys(
+	translations map[string]TranslateStrings,
+	positions map[string][]Position,
+	policy DuplicateKeyPolicy,
+) (map[string]TranslateStrings, error) {
+	languageNames := make([]string, 0, len(translations))
+	for languageName := range translations {
+		languageNames = append(languageNames, languageName)
+	}
+	sort.Strings(languageNames)
+
+	var duplicates []DuplicateKey
+	for _, languageName := range languageNames {
+		resolved, err := resolveDuplicateKeys(
+			translations[languageName],
+			positions[languageName],
+			policy,
+		)
+		var duplicatesError *DuplicateKeysError
+		if errors.As(err, &duplicatesError) {
+			for _, duplicate := range duplicatesError.Duplicates {
+				duplicate.Language = languageName
+				duplicates = append(duplicates, duplicate)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		translations[languageName] = resolved
+	}
+	if len(duplicates) > 0 {
+		return nil, &DuplicateKeysError{Duplicates: duplicates}
+	}
+
+	return translations, nil
+}
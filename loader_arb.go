@@ -0,0 +1,151 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// LoadFromArbBytes loads a list of TranslateString from the provided
+// ARB bytes, the JSON format with ICU messages used by Flutter:
+//
+//	{
+//	  "@@locale": "en",
+//	  "hello": "Hello {name}",
+//	  "@hello": {"description": "Greeting of the home page"},
+//	  "emails": "{count, plural, one {One email} other {{count} emails}}"
+//	}
+//
+// The ICU messages are converted to templates, the placeholders are
+// fields of the data ({{.name}}) and the plural and gender select
+// arguments become the variants (see icuToTranslateString). The
+// "description", "context" and "screen" attributes are the
+// Description, Context and ScreenshotRef fields, and the custom
+// "x-maxLength", "x-tags" and "x-status" attributes are the
+// MaxLength, Tags and Status fields.
+func LoadFromArbBytes(
+	arbBytes []byte,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeArb, arbBytes, defaultLoadOptions)
+}
+
+// LoadFromArbString loads a list of TranslateString
+// from the provided ARB string.
+func LoadFromArbString(
+	arbString string,
+) (TranslateStrings, error) {
+	return LoadFromArbBytes([]byte(arbString))
+}
+
+// LoadFromArbFiles loads a list of TranslateString from
+// one or multiple ARB files, allowing glob patterns
+// like "path/to/files/*.arb".
+func LoadFromArbFiles(
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeArb, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromArbFS loads a list of TranslateString from
+// one or multiple ARB files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.arb".
+func LoadFromArbFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeArb, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromArbBytesWithOptions is LoadFromArbBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromArbBytesWithOptions(
+	arbBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeArb, arbBytes, options)
+}
+
+// LoadFromArbFilesWithOptions is LoadFromArbFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromArbFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeArb, filesOrGlobs, options)
+}
+
+// LoadFromArbFSWithOptions is LoadFromArbFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromArbFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeArb, fileSystem, filesOrGlobs, options)
+}
+
+// arbMetadata are the attributes of a message of an ARB file
+type arbMetadata struct {
+	Description  string                     `json:"description,omitempty"`
+	Context      string                     `json:"context,omitempty"`
+	Screen       string                     `json:"screen,omitempty"`
+	MaxLength    int                        `json:"x-maxLength,omitempty"`
+	Tags         []string                   `json:"x-tags,omitempty"`
+	Status       TranslationStatus          `json:"x-status,omitempty"`
+	Placeholders map[string]json.RawMessage `json:"placeholders,omitempty"`
+}
+
+func decodeArb(arbBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	if len(bytes.TrimSpace(arbBytes)) == 0 {
+		return nil, nil, nil
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(arbBytes, &entries); err != nil {
+		return nil, nil, jsonLoadError(arbBytes, 0, "", err)
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		if !strings.HasPrefix(key, "@") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	translateStrings := TranslateStrings{}
+	for _, key := range keys {
+		var message string
+		if err := json.Unmarshal(entries[key], &message); err != nil {
+			return nil, nil, &LoadError{Key: key, Err: fmt.Errorf("arb: the message must be a text")}
+		}
+
+		ts, err := icuToTranslateString(message)
+		if err != nil {
+			return nil, nil, &LoadError{Key: key, Err: fmt.Errorf("arb: %w", err)}
+		}
+		ts.Key = key
+
+		if rawMetadata, exists := entries["@"+key]; exists {
+			var metadata arbMetadata
+			if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
+				return nil, nil, &LoadError{Key: key, Err: fmt.Errorf("arb: invalid attributes: %w", err)}
+			}
+			ts.Description = metadata.Description
+			ts.Context = metadata.Context
+			ts.ScreenshotRef = metadata.Screen
+			ts.MaxLength = metadata.MaxLength
+			ts.Tags = metadata.Tags
+			ts.Status = metadata.Status
+		}
+
+		translateStrings = append(translateStrings, ts)
+	}
+
+	// The objects don't keep the positions of their fields
+	return translateStrings, make([]Position, len(translateStrings)), nil
+}
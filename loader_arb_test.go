@@ -0,0 +1,52 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadFromArb(t *testing.T) {
+	t.Run("load messages and attributes", func(t *testing.T) {
+		translateStrings, err := LoadFromArbString(`{
+			"@@locale": "es",
+			"hello": "Hola {name}",
+			"@hello": {
+				"description": "Greeting of the home page",
+				"screen": "home.png",
+				"placeholders": {"name": {"type": "String"}}
+			},
+			"emails": "{count, plural, one {Un correo} other {{count} correos}}",
+			"open": "Abrir",
+			"@open": {"context": "verb"}
+		}`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := TranslateStrings{
+			{Key: "emails", One: "Un correo", Many: "{{.count}} correos"},
+			{Key: "hello", Default: "Hola {{.name}}", Description: "Greeting of the home page", ScreenshotRef: "home.png"},
+			{Key: "open", Context: "verb", Default: "Abrir"},
+		}
+		if !reflect.DeepEqual(translateStrings, expected) {
+			t.Errorf("Unexpected result: %#v", translateStrings)
+		}
+	})
+
+	t.Run("handle incorrect arb", func(t *testing.T) {
+		_, err := LoadFromArbString(`{"hello": "Hola {name"}`)
+		if err == nil || err.Error() != "goeasyi18n: key 'hello': arb: icu: the argument 'name' is not closed" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+
+		_, err = LoadFromArbString(`{"hello": 10}`)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+
+		_, err = LoadFromArbString(`["hello"]`)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+)
+
+// LoadFromCsvBytes loads the translations of multiple languages
+// from the provided CSV bytes, it returns a map with the
+// language name as the key.
+//
+// The first row is the header, it must have a "Key" column and
+// one column per language, additional columns named like "lang.Variant"
+// can be used to set the other fields of the TranslateString:
+//
+//	Key,en,es,en.One,en.Many,es.One,es.Many
+//	hello,Hello,Hola,,,,
+//	emails,,,One email,Many emails,Un correo,Muchos correos
+//
+// An optional "Context" column sets the context of the translations
+// of the row, so the same key can be repeated with different contexts.
+// The metadata for the translators can be set in the same way with the
+// "Description", "MaxLength", "Tags" (comma separated) and
+// "ScreenshotRef" columns.
+//
+// Empty cells are ignored and a key that has no values for a
+// language is not added to that language.
+func LoadFromCsvBytes(
+	csvBytes []byte,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromBytes(decodeCsv, csvBytes, defaultLoadOptions)
+}
+
+// decodeCsv decodes the translations of all the languages of a CSV
+// and returns the position where every translation starts
+func decodeCsv(
+	csvBytes []byte,
+) (map[string]TranslateStrings, map[string][]Position, error) {
+	reader := csv.NewReader(bytes.NewReader(csvBytes))
+	reader.FieldsPerRecord = -1
+
+	// The records are read one by one to know their positions
+	var records [][]string
+	var recordPositions [][]Position
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			return nil, nil, &LoadError{
+				Position: Position{Line: parseError.Line, Column: parseError.Column},
+				Err:      fmt.Errorf("csv: %w", parseError.Err),
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fieldPositions := make([]Position, len(record))
+		for i := range record {
+			line, column := reader.FieldPos(i)
+			fieldPositions[i] = Position{Line: line, Column: column}
+		}
+		records = append(records, record)
+		recordPositions = append(recordPositions, fieldPositions)
+	}
+
+	if len(records) == 0 {
+		return map[string]TranslateStrings{}, map[string][]Position{}, nil
+	}
+
+	type csvColumn struct {
+		language string
+		variant  string
+	}
+
+	keyColumn := -1
+	// extraColumns are the columns of the context and the
+	// metadata, they are shared by all the languages
+	extraColumns := map[int]string{}
+	columns := make([]csvColumn, len(records[0]))
+	languages := []string{}
+
+	for i, header := range records[0] {
+		header = strings.TrimSpace(strings.TrimPrefix(header, "\ufeff"))
+
+		if strings.EqualFold(header, "Key") {
+			keyColumn = i
+			continue
+		}
+		if extraField := csvExtraFieldName(header); extraField != "" {
+			extraColumns[i] = extraField
+			continue
+		}
+
+		language, variant, hasVariant := strings.Cut(header, ".")
+		if !hasVariant {
+			variant = "Default"
+		}
+		if language == "" {
+			continue
+		}
+		if !isVariantName(variant) {
+			return nil, nil, &LoadError{
+				Position: recordPositions[0][i],
+				Err: fmt.Errorf(
+					"csv: the column '%s' has an unknown variant '%s'",
+					header,
+					variant,
+				),
+			}
+		}
+
+		columns[i] = csvColumn{language: language, variant: variant}
+
+		found := false
+		for _, l := range languages {
+			if l == language {
+				found = true
+				break
+			}
+		}
+		if !found {
+			languages = append(languages, language)
+		}
+	}
+
+	if keyColumn < 0 {
+		return nil, nil, &LoadError{
+			Position: Position{Line: 1, Column: 1},
+			Err:      fmt.Errorf("csv: the header doesn't have a 'Key' column"),
+		}
+	}
+
+	translations := make(map[string]TranslateStrings, len(languages))
+	positions := make(map[string][]Position, len(languages))
+	for _, language := range languages {
+		translations[language] = TranslateStrings{}
+		positions[language] = []Position{}
+	}
+
+	for rowIndex, record := range records[1:] {
+		rowPosition := recordPositions[rowIndex+1][0]
+		if keyColumn >= len(record) || strings.TrimSpace(record[keyColumn]) == "" {
+			isEmpty := true
+			for _, value := range record {
+				if value != "" {
+					isEmpty = false
+					break
+				}
+			}
+			if isEmpty {
+				continue
+			}
+			return nil, nil, &LoadError{
+				Position: rowPosition,
+				Err:      fmt.Errorf("csv: the row doesn't have a key"),
+			}
+		}
+
+		if len(record) != len(records[0]) {
+			return nil, nil, &LoadError{
+				Position: rowPosition,
+				Err: fmt.Errorf(
+					"csv: the row has %d fields but the header has %d",
+					len(record),
+					len(records[0]),
+				),
+			}
+		}
+
+		key := strings.TrimSpace(record[keyColumn])
+		rowTemplate := TranslateString{Key: key}
+		for i, extraField := range extraColumns {
+			if i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			err := setTranslateStringField(&rowTemplate, extraField, strings.TrimSpace(record[i]))
+			if err != nil {
+				return nil, nil, &LoadError{
+					Position: recordPositions[rowIndex+1][i],
+					Key:      key,
+					Err:      fmt.Errorf("csv: %w", err),
+				}
+			}
+		}
+		rowStrings := map[string]*TranslateString{}
+
+		for i, value := range record {
+			if i >= len(columns) || columns[i].language == "" || value == "" {
+				continue
+			}
+
+			ts, exists := rowStrings[columns[i].language]
+			if !exists {
+				// Every language has its own copy of the metadata
+				rowCopy := rowTemplate
+				if rowTemplate.Tags != nil {
+					rowCopy.Tags = append([]string(nil), rowTemplate.Tags...)
+				}
+				ts = &rowCopy
+				rowStrings[columns[i].language] = ts
+			}
+			reflect.ValueOf(ts).Elem().FieldByName(columns[i].variant).SetString(value)
+		}
+
+		for _, language := range languages {
+			if ts, exists := rowStrings[language]; exists {
+				translations[language] = append(translations[language], *ts)
+				positions[language] = append(positions[language], rowPosition)
+			}
+		}
+	}
+
+	return translations, positions, nil
+}
+
+// csvExtraFieldName returns the field of the context or metadata
+// column of the header, or an empty string for other columns
+func csvExtraFieldName(header string) string {
+	for _, extraFieldName := range extraFieldNames {
+		if strings.EqualFold(header, extraFieldName) {
+			return extraFieldName
+		}
+	}
+	return ""
+}
+
+// LoadFromCsvString loads the translations of multiple languages
+// from the provided CSV string.
+func LoadFromCsvString(
+	csvString string,
+) (map[string]TranslateStrings, error) {
+	return LoadFromCsvBytes([]byte(csvString))
+}
+
+// LoadFromCsvFiles loads the translations of multiple languages from
+// one or multiple CSV files, allowing glob patterns
+// like "path/to/files/*.csv".
+func LoadFromCsvFiles(
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFiles(decodeCsv, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromCsvFS loads the translations of multiple languages from
+// one or multiple CSV files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.csv".
+func LoadFromCsvFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFS(decodeCsv, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromCsvBytesWithOptions is LoadFromCsvBytes with options,
+// like the policy for the duplicated keys.
+func LoadFromCsvBytesWithOptions(
+	csvBytes []byte,
+	options LoadOptions,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromBytes(decodeCsv, csvBytes, options)
+}
+
+// LoadFromCsvFilesWithOptions is LoadFromCsvFiles with options,
+// like the policy for the duplicated keys.
+func LoadFromCsvFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFiles(decodeCsv, filesOrGlobs, options)
+}
+
+// LoadFromCsvFSWithOptions is LoadFromCsvFS with options,
+// like the policy for the duplicated keys.
+func LoadFromCsvFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFS(decodeCsv, fileSystem, filesOrGlobs, options)
+}
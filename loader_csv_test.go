@@ -0,0 +1,169 @@
+package goeasyi18n
+
+import (
+	"embed"
+	"errors"
+	"testing"
+)
+
+func TestLoadFromCsvBytes(t *testing.T) {
+	bytes := []byte("Key,en,es\nhello,Hello,Hola\n")
+	translations, err := LoadFromCsvBytes(bytes)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(translations) != 2 {
+		t.Errorf("Unexpected result: %v", translations)
+	}
+	if len(translations["en"]) != 1 || translations["en"][0].Default != "Hello" {
+		t.Errorf("Unexpected result: %v", translations)
+	}
+	if len(translations["es"]) != 1 || translations["es"][0].Default != "Hola" {
+		t.Errorf("Unexpected result: %v", translations)
+	}
+}
+
+func TestLoadFromCsvString(t *testing.T) {
+	translations, err := LoadFromCsvString("Key,en,es\nhello,Hello,Hola\n")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(translations["en"]) != 1 || translations["en"][0].Key != "hello" {
+		t.Errorf("Unexpected result: %v", translations)
+	}
+	if len(translations["es"]) != 1 || translations["es"][0].Default != "Hola" {
+		t.Errorf("Unexpected result: %v", translations)
+	}
+}
+
+func TestLoadFromCsvVariants(t *testing.T) {
+	t.Run("load variant columns and skip empty cells", func(t *testing.T) {
+		translations, err := LoadFromCsvString(
+			"Key,en,es,en.One,en.Many,es.One,es.Many\n" +
+				"hello,Hello,Hola,,,,\n" +
+				"emails,,,One email,Many emails,,\n" +
+				",,,,,,\n" +
+				"only_en,Only English,,,,,\n",
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(translations["en"]) != 3 {
+			t.Errorf("Unexpected result: %v", translations["en"])
+		}
+		if translations["en"][1].One != "One email" || translations["en"][1].Many != "Many emails" {
+			t.Errorf("Unexpected result: %v", translations["en"][1])
+		}
+		if len(translations["es"]) != 1 || translations["es"][0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", translations["es"])
+		}
+	})
+
+	t.Run("handle missing key column", func(t *testing.T) {
+		_, err := LoadFromCsvString("en,es\nHello,Hola\n")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle rows without key", func(t *testing.T) {
+		_, err := LoadFromCsvString("Key,en\n,Hello\n")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle rows with missing or extra fields", func(t *testing.T) {
+		tests := map[string]string{
+			"Key,en,es\nhello,Hello,Hola\nbye,Bye\n": "goeasyi18n: line 3, column 1: csv: the row has 2 fields but the header has 3",
+			"Key,en\nhello,Hello\nbye,Bye,Adiós\n":   "goeasyi18n: line 3, column 1: csv: the row has 3 fields but the header has 2",
+		}
+		for csv, expected := range tests {
+			_, err := LoadFromCsvString(csv)
+			var loadError *LoadError
+			if !errors.As(err, &loadError) || err.Error() != expected {
+				t.Errorf("expected %s; got %v", expected, err)
+			}
+		}
+	})
+}
+
+func TestLoadFromCsvFiles(t *testing.T) {
+	t.Run("load multiple files", func(t *testing.T) {
+		translations, err := LoadFromCsvFiles(
+			"./testfiles/test1.csv",
+			"./testfiles/test2.csv",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(translations["en"]) != 2 || len(translations["es"]) != 2 {
+			t.Errorf("Unexpected result: %v", translations)
+		}
+		if translations["es"][1].Default != "Mundo" {
+			t.Errorf("Unexpected result: %v", translations)
+		}
+	})
+
+	t.Run("load with glob pattern", func(t *testing.T) {
+		translations, err := LoadFromCsvFiles("./testfiles/test*.csv")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(translations["en"]) != 2 {
+			t.Errorf("Unexpected result: %v", translations)
+		}
+	})
+
+	t.Run("handle incorrect csv", func(t *testing.T) {
+		_, err := LoadFromCsvFiles("./testfiles/incorrect.csv")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromCsvFiles("./testfiles/nomatch*.csv")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+//go:embed testfiles/*
+var csvTestFiles embed.FS
+
+func TestLoadFromCsvFS(t *testing.T) {
+	t.Run("load with glob pattern", func(t *testing.T) {
+		translations, err := LoadFromCsvFS(
+			csvTestFiles,
+			"testfiles/test*.csv",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(translations["en"]) != 2 || len(translations["es"]) != 2 {
+			t.Errorf("Unexpected result: %v", translations)
+		}
+	})
+
+	t.Run("handle incorrect csv", func(t *testing.T) {
+		_, err := LoadFromCsvFS(
+			csvTestFiles,
+			"testfiles/incorrect.csv",
+		)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromCsvFS(
+			csvTestFiles,
+			"testfiles/nomatch*.csv",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
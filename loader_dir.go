@@ -0,0 +1,186 @@
+package goeasyi18n
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// catalogDecoders are the loaders used by LoadDir and LoadFS
+// for the files with a single language, by file extension
+var catalogDecoders = map[string]catalogDecoder{
+	".json":       decodeJson,
+	".yaml":       decodeYaml,
+	".yml":        decodeYaml,
+	".toml":       decodeToml,
+	".properties": decodeProperties,
+	".po":         decodePo,
+}
+
+// LoadDir loads all the translation files of a directory
+// (and its subdirectories) and adds their languages to the
+// i18n object, see LoadFS for the details.
+func (t *I18n) LoadDir(dir string) error {
+	return t.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS loads all the translation files located within the root
+// directory (and its subdirectories) of the provided filesystem
+// (fs.FS) and adds their languages to the i18n object.
+//
+// The loader is picked by the file extension (.json, .yaml, .yml,
+// .toml, .properties, .po, .ftl and .csv) and the other files are
+// ignored.
+//
+// The language is inferred from the directory or file name:
+//
+//   - translations/es/messages.json is loaded as "es"
+//   - translations/en.yaml is loaded as "en"
+//   - translations/messages.en.yaml is loaded as "en"
+//   - translations/es/LC_MESSAGES/messages.po is loaded as "es"
+//   - CSV files can have multiple languages, so they are
+//     loaded with the languages of their header
+//
+// All the files of a language are merged before adding it, the keys
+// that are defined more than once are handled using the
+// DuplicateKeyPolicy of the config (a single *DuplicateKeysError
+// has the duplicates of all the languages). The errors are *LoadError
+// with the position of the problem and, with the StrictLoading
+// config, the unknown fields (like "Mnay") are rejected. With the
+// CollectLoadErrors config the catalogs are added despite the unknown
+// fields and the duplicated keys, and they are returned together as
+// a *LoadErrors.
+func (t *I18n) LoadFS(fileSystem fs.FS, root string) error {
+	translations := map[string]TranslateStrings{}
+	positions := map[string][]Position{}
+	fluentResources := map[string]*FluentResource{}
+	var loadErrors []error
+
+	err := fs.WalkDir(fileSystem, root, func(
+		filePath string,
+		entry fs.DirEntry,
+		err error,
+	) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		extension := strings.ToLower(path.Ext(filePath))
+		decoder, isCatalog := catalogDecoders[extension]
+		if !isCatalog && extension != ".ftl" && extension != ".csv" {
+			return nil
+		}
+
+		byteValue, err := readFileFromFS(fileSystem, filePath)
+		if err != nil {
+			return err
+		}
+
+		if extension == ".csv" {
+			csvTranslations, csvPositions, err := decodeCsv(byteValue)
+			if err != nil {
+				return withFile(err, filePath)
+			}
+			for languageName, translateStrings := range csvTranslations {
+				translations[languageName] = append(translations[languageName], translateStrings...)
+				positions[languageName] = append(positions[languageName], positionsWithFile(csvPositions[languageName], filePath)...)
+			}
+			return nil
+		}
+
+		languageName := languageNameFromPath(root, filePath)
+
+		if extension == ".ftl" {
+			resource, err := LoadFromFluentBytes(byteValue)
+			if err != nil {
+				return withFile(err, filePath)
+			}
+			if fluentResources[languageName] == nil {
+				fluentResources[languageName] = newFluentResource()
+			}
+			if err := fluentResources[languageName].merge(resource); err != nil {
+				return withFile(err, filePath)
+			}
+			return nil
+		}
+
+		translateStrings, filePositions, err := decoder(byteValue, t.strictLoading)
+		if err != nil && t.collectLoadErrors && errors.Is(err, errUnknownField) {
+			// The file is loaded again without the unknown fields
+			loadErrors = append(loadErrors, withFile(err, filePath))
+			translateStrings, filePositions, err = decoder(byteValue, false)
+		}
+		if err != nil {
+			return withFile(err, filePath)
+		}
+		translations[languageName] = append(translations[languageName], translateStrings...)
+		positions[languageName] = append(positions[languageName], positionsWithFile(filePositions, filePath)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The duplicated keys of all the languages are reported together
+	policy := t.duplicateKeyPolicy
+	if t.collectLoadErrors && policy == DuplicateKeyPolicyError {
+		if _, err := resolveMultipleDuplicateKeys(translations, positions, policy); err != nil {
+			loadErrors = append(loadErrors, err)
+		}
+		policy = DuplicateKeyPolicyFirstWins
+	}
+	translations, err = resolveMultipleDuplicateKeys(translations, positions, policy)
+	if err != nil {
+		return err
+	}
+
+	languageNames := make([]string, 0, len(translations))
+	for languageName := range translations {
+		languageNames = append(languageNames, languageName)
+	}
+	sort.Strings(languageNames)
+
+	for _, languageName := range languageNames {
+		t.AddLanguage(languageName, translations[languageName])
+	}
+
+	fluentLanguageNames := make([]string, 0, len(fluentResources))
+	for languageName := range fluentResources {
+		fluentLanguageNames = append(fluentLanguageNames, languageName)
+	}
+	sort.Strings(fluentLanguageNames)
+	for _, languageName := range fluentLanguageNames {
+		t.AddFluentLanguage(languageName, fluentResources[languageName])
+	}
+
+	if len(loadErrors) > 0 {
+		return &LoadErrors{Errors: loadErrors}
+	}
+	return nil
+}
+
+// languageNameFromPath infers the language of a file, it is the first
+// directory inside the root or, for the files that are directly inside
+// the root, the last dot separated part of the file name
+func languageNameFromPath(root string, filePath string) string {
+	relativePath := filePath
+	if root != "." {
+		relativePath = strings.TrimPrefix(filePath, strings.TrimSuffix(root, "/")+"/")
+	}
+
+	if dir, _, isNested := strings.Cut(relativePath, "/"); isNested {
+		return dir
+	}
+
+	name := strings.TrimSuffix(relativePath, path.Ext(relativePath))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		return name[dot+1:]
+	}
+	return name
+}
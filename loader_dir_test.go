@@ -0,0 +1,147 @@
+package goeasyi18n
+
+import (
+	"embed"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadDir(t *testing.T) {
+	t.Run("load every language of the directory", func(t *testing.T) {
+		i18n := NewI18n(Config{DisableConsistencyCheck: true})
+		err := i18n.LoadDir("./testfiles/dir")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			lang     string
+			key      string
+			expected string
+		}{
+			{"en", "hello", "Hello"},
+			{"es", "hello", "Hola"},
+			{"es", "bye", "Adiós"},
+			{"pt", "hello", "Olá"},
+			{"fr", "hello", "Bonjour"},
+			{"de", "hello", "Hallo"},
+			{"it", "hello", "Ciao"},
+			{"nl", "hello", "Hallo"},
+		}
+
+		for _, test := range tests {
+			if !i18n.HasLanguage(test.lang) {
+				t.Errorf("expected language %s to exist", test.lang)
+			}
+			got := i18n.Translate(test.lang, test.key)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+
+		if i18n.HasLanguage("README") {
+			t.Errorf("expected unknown files to be ignored")
+		}
+	})
+
+	t.Run("handle non existent directory", func(t *testing.T) {
+		i18n := NewI18n()
+		err := i18n.LoadDir("./testfiles/not-exists")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
+
+//go:embed testfiles/*
+var dirTestFiles embed.FS
+
+func TestLoadFS(t *testing.T) {
+	t.Run("load from a subdirectory of the filesystem", func(t *testing.T) {
+		i18n := NewI18n(Config{DisableConsistencyCheck: true})
+		err := i18n.LoadFS(dirTestFiles, "testfiles/dir")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		names := i18n.LanguageNames()
+		if len(names) != 7 {
+			t.Errorf("Unexpected result: %v", names)
+		}
+		if got := i18n.Translate("es", "bye"); got != "Adiós" {
+			t.Errorf("expected Adiós; got %s", got)
+		}
+	})
+
+	t.Run("handle incorrect files", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"i18n/en.json": {Data: []byte("incorrect json")},
+		}
+		i18n := NewI18n()
+		err := i18n.LoadFS(fileSystem, "i18n")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("collect the unknown fields and the duplicated keys", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"en.yaml": {Data: []byte("- Key: hello\n  Default: Hello\n  Mnay: x\n- Key: hello\n  Default: Hi\n")},
+			"es.json": {Data: []byte(`[{"Key": "hello", "Default": "Hola"}]`)},
+		}
+		i18n := NewI18n(Config{DisableConsistencyCheck: true, StrictLoading: true, CollectLoadErrors: true})
+		err := i18n.LoadFS(fileSystem, ".")
+
+		var loadErrors *LoadErrors
+		if !errors.As(err, &loadErrors) || len(loadErrors.Errors) != 2 {
+			t.Fatalf("expected 2 load errors; got %v", err)
+		}
+		expected := "goeasyi18n: en.yaml:3:3: key 'hello': unknown field 'Mnay'\n" +
+			"goeasyi18n: the key 'hello' is defined more than once in the language 'en' (en.yaml:1:3; en.yaml:4:3)"
+		if err.Error() != expected {
+			t.Errorf("expected %s; got %s", expected, err.Error())
+		}
+		if got := i18n.T("en", "hello"); got != "Hello" {
+			t.Errorf("expected %s; got %s", "Hello", got)
+		}
+		if got := len(i18n.Translations("en")); got != 1 {
+			t.Errorf("expected %d; got %d", 1, got)
+		}
+	})
+
+	t.Run("report the file of the duplicated Fluent messages", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"i18n/en/a.ftl": {Data: []byte("hello = Hello\n")},
+			"i18n/en/b.ftl": {Data: []byte("hello = Hi\n")},
+		}
+		err := NewI18n().LoadFS(fileSystem, "i18n")
+		expected := "goeasyi18n: i18n/en/b.ftl: fluent: the message 'hello' is defined more than once"
+		var loadError *LoadError
+		if !errors.As(err, &loadError) || err.Error() != expected {
+			t.Errorf("expected %s; got %v", expected, err)
+		}
+	})
+}
+
+func TestLanguageNameFromPath(t *testing.T) {
+	tests := []struct {
+		root     string
+		path     string
+		expected string
+	}{
+		{".", "en.yaml", "en"},
+		{".", "es/messages.json", "es"},
+		{".", "es/nested/messages.json", "es"},
+		{"translations", "translations/pt-BR.toml", "pt-BR"},
+		{"translations/", "translations/messages.fr.yaml", "fr"},
+		{"translations", "translations/de/a.b.json", "de"},
+	}
+
+	for _, test := range tests {
+		got := languageNameFromPath(test.root, test.path)
+		if got != test.expected {
+			t.Errorf("expected %s; got %s", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package goeasyi18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LoadFromFluentBytes loads a Mozilla Fluent resource
+// from the provided .ftl bytes.
+func LoadFromFluentBytes(
+	fluentBytes []byte,
+) (*FluentResource, error) {
+	return parseFluent(string(fluentBytes))
+}
+
+// LoadFromFluentString loads a Mozilla Fluent resource
+// from the provided .ftl string.
+func LoadFromFluentString(
+	fluentString string,
+) (*FluentResource, error) {
+	return LoadFromFluentBytes([]byte(fluentString))
+}
+
+// LoadFromFluentFiles loads a Mozilla Fluent resource from
+// one or multiple .ftl files, allowing glob patterns
+// like "path/to/files/*.ftl".
+func LoadFromFluentFiles(
+	filesOrGlobs ...string,
+) (*FluentResource, error) {
+	allResources := newFluentResource()
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range matches {
+			byteValue, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			resource, err := LoadFromFluentBytes(byteValue)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			err = allResources.merge(resource)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+		}
+	}
+
+	return allResources, nil
+}
+
+// LoadFromFluentFS loads a Mozilla Fluent resource from
+// one or multiple .ftl files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.ftl".
+func LoadFromFluentFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (*FluentResource, error) {
+	allResources := newFluentResource()
+
+	for _, pattern := range filesOrGlobs {
+		matches, err := fs.Glob(fileSystem, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		for _, file := range matches {
+			byteValue, err := readFileFromFS(fileSystem, file)
+			if err != nil {
+				return nil, err
+			}
+
+			resource, err := LoadFromFluentBytes(byteValue)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+
+			err = allResources.merge(resource)
+			if err != nil {
+				return nil, withFile(err, file)
+			}
+		}
+	}
+
+	return allResources, nil
+}
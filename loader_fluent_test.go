@@ -0,0 +1,114 @@
+package goeasyi18n
+
+import (
+	"embed"
+	"reflect"
+	"testing"
+)
+
+func TestLoadFromFluentBytes(t *testing.T) {
+	resource, err := LoadFromFluentBytes([]byte("hello = Hello\n"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if ids := resource.MessageIDs(); len(ids) != 1 || ids[0] != "hello" {
+		t.Errorf("Unexpected result: %v", ids)
+	}
+}
+
+func TestLoadFromFluentString(t *testing.T) {
+	resource, err := LoadFromFluentString("hello = Hello\n")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if ids := resource.MessageIDs(); len(ids) != 1 || ids[0] != "hello" {
+		t.Errorf("Unexpected result: %v", ids)
+	}
+}
+
+func TestLoadFromFluentFiles(t *testing.T) {
+	t.Run("load single file", func(t *testing.T) {
+		resource, err := LoadFromFluentFiles("./testfiles/test1.ftl")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if ids := resource.MessageIDs(); len(ids) != 1 || ids[0] != "hello" {
+			t.Errorf("Unexpected result: %v", ids)
+		}
+	})
+
+	t.Run("load multiple files", func(t *testing.T) {
+		resource, err := LoadFromFluentFiles(
+			"./testfiles/test1.ftl",
+			"./testfiles/test2.ftl",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if ids := resource.MessageIDs(); !reflect.DeepEqual(ids, []string{"hello", "world"}) {
+			t.Errorf("Unexpected result: %v", ids)
+		}
+	})
+
+	t.Run("handle duplicated messages in multiple files", func(t *testing.T) {
+		_, err := LoadFromFluentFiles(
+			"./testfiles/test1.ftl",
+			"./testfiles/test1.ftl",
+		)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle incorrect fluent", func(t *testing.T) {
+		_, err := LoadFromFluentFiles("./testfiles/incorrect.ftl")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromFluentFiles("./testfiles/nomatch*.ftl")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+//go:embed testfiles/*
+var fluentTestFiles embed.FS
+
+func TestLoadFromFluentFS(t *testing.T) {
+	t.Run("load with glob pattern", func(t *testing.T) {
+		resource, err := LoadFromFluentFS(
+			fluentTestFiles,
+			"testfiles/test*.ftl",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if ids := resource.MessageIDs(); len(ids) != 2 {
+			t.Errorf("Unexpected result: %v", ids)
+		}
+	})
+
+	t.Run("handle incorrect fluent", func(t *testing.T) {
+		_, err := LoadFromFluentFS(
+			fluentTestFiles,
+			"testfiles/incorrect.ftl",
+		)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromFluentFS(
+			fluentTestFiles,
+			"testfiles/nomatch*.ftl",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
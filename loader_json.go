@@ -1,10 +1,12 @@
 package goeasyi18n
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"io/fs"
-	"os"
-	"path/filepath"
+	"strings"
 )
 
 // LoadFromJsonBytes loads a list of TranslateString
@@ -12,13 +14,7 @@ import (
 func LoadFromJsonBytes(
 	jsonBytes []byte,
 ) (TranslateStrings, error) {
-	var translateStrings TranslateStrings
-	err := json.Unmarshal(jsonBytes, &translateStrings)
-	if err != nil {
-		return nil, err
-	}
-
-	return translateStrings, nil
+	return loadFromBytes(decodeJson, jsonBytes, defaultLoadOptions)
 }
 
 // LoadFromJsonString loads a list of TranslateString
@@ -35,30 +31,7 @@ func LoadFromJsonString(
 func LoadFromJsonFiles(
 	filesOrGlobs ...string,
 ) (TranslateStrings, error) {
-	var allTranslateStrings TranslateStrings
-
-	for _, pattern := range filesOrGlobs {
-		matches, err := filepath.Glob(pattern)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, file := range matches {
-			byteValue, err := os.ReadFile(file)
-			if err != nil {
-				return nil, err
-			}
-
-			translateString, err := LoadFromJsonBytes(byteValue)
-			if err != nil {
-				return nil, err
-			}
-
-			allTranslateStrings = append(allTranslateStrings, translateString...)
-		}
-	}
-
-	return allTranslateStrings, nil
+	return loadFromFiles(decodeJson, filesOrGlobs, defaultLoadOptions)
 }
 
 // LoadFromJsonFS loads a list of TranslateString from
@@ -69,32 +42,183 @@ func LoadFromJsonFS(
 	fileSystem fs.FS,
 	filesOrGlobs ...string,
 ) (TranslateStrings, error) {
-	var allTranslateStrings TranslateStrings
+	return loadFromFS(decodeJson, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromJsonBytesWithOptions is LoadFromJsonBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromJsonBytesWithOptions(
+	jsonBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeJson, jsonBytes, options)
+}
+
+// LoadFromJsonFilesWithOptions is LoadFromJsonFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromJsonFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeJson, filesOrGlobs, options)
+}
+
+// LoadFromJsonFSWithOptions is LoadFromJsonFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromJsonFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeJson, fileSystem, filesOrGlobs, options)
+}
+
+// decodeJson decodes the translations one by one
+// to know the position where every translation starts
+func decodeJson(jsonBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
+	}
+	if token == nil {
+		return nil, nil, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		// Unmarshal returns the same error as before for
+		// the values that are not arrays
+		var translateStrings TranslateStrings
+		err := json.Unmarshal(jsonBytes, &translateStrings)
+		return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
+	}
 
-	for _, pattern := range filesOrGlobs {
-		matches, err := fs.Glob(fileSystem, pattern)
+	translateStrings := TranslateStrings{}
+	positions := []Position{}
+	tracker := newPositionTracker(jsonBytes)
+
+	for decoder.More() {
+		start := skipJsonSeparators(jsonBytes, int(decoder.InputOffset()))
+
+		var translateString TranslateString
+		err := decoder.Decode(&translateString)
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			// The offsets of the type errors are relative to the
+			// translation, the decoder already skipped it
+			raw := json.RawMessage(jsonBytes[start:decoder.InputOffset()])
+			return nil, nil, jsonLoadError(jsonBytes, start, jsonTranslationKey(raw), err)
+		}
 		if err != nil {
-			return nil, err
+			return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
 		}
 
-		if len(matches) == 0 {
-			continue
+		if strict {
+			raw := json.RawMessage(jsonBytes[start:decoder.InputOffset()])
+			for _, field := range jsonObjectFields(raw) {
+				if !isTranslateStringField(field.name) {
+					return nil, nil, &LoadError{
+						Position: positionAt(jsonBytes, start+field.offset),
+						Key:      translateString.Key,
+						Err:      unknownFieldError(field.name),
+					}
+				}
+			}
 		}
 
-		for _, file := range matches {
-			byteValue, err := readFileFromFS(fileSystem, file)
-			if err != nil {
-				return nil, err
-			}
+		translateStrings = append(translateStrings, translateString)
+		positions = append(positions, tracker.at(start))
+	}
 
-			translateString, err := LoadFromJsonBytes(byteValue)
-			if err != nil {
-				return nil, err
-			}
+	// Closing bracket
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
+	}
+
+	_, err = decoder.Token()
+	if !errors.Is(err, io.EOF) {
+		var translateStrings TranslateStrings
+		err := json.Unmarshal(jsonBytes, &translateStrings)
+		return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
+	}
+
+	return translateStrings, positions, nil
+}
+
+// jsonLoadError adds the position to the errors of the JSON
+// decoding, base is the offset where the decoded value starts
+func jsonLoadError(jsonBytes []byte, base int, key string, err error) error {
+	offset := base
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxError):
+		offset += int(syntaxError.Offset)
+	case errors.As(err, &typeError):
+		offset += int(typeError.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		offset = len(jsonBytes)
+	}
+
+	return &LoadError{
+		Position: positionAt(jsonBytes, offset),
+		Key:      key,
+		Err:      err,
+	}
+}
+
+func skipJsonSeparators(jsonBytes []byte, offset int) int {
+	for offset < len(jsonBytes) && bytes.IndexByte([]byte(", \t\r\n"), jsonBytes[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// jsonTranslationKey gets the key of a translation
+// even if it can't be decoded as a TranslateString
+func jsonTranslationKey(raw json.RawMessage) string {
+	var fields map[string]any
+	_ = json.Unmarshal(raw, &fields)
+	for name, value := range fields {
+		if key, ok := value.(string); ok && strings.EqualFold(name, "Key") {
+			return key
+		}
+	}
+	return ""
+}
+
+// jsonObjectField is a field of a JSON object with
+// the offset of its name inside the object
+type jsonObjectField struct {
+	name   string
+	offset int
+}
+
+// jsonObjectFields returns the fields of a JSON object
+// in the order they are declared
+func jsonObjectFields(raw json.RawMessage) []jsonObjectField {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+
+	var fields []jsonObjectField
+	for decoder.More() {
+		offset := skipJsonSeparators(raw, int(decoder.InputOffset()))
+		token, err := decoder.Token()
+		if err != nil {
+			return fields
+		}
+		name, _ := token.(string)
+		fields = append(fields, jsonObjectField{name: name, offset: offset})
 
-			allTranslateStrings = append(allTranslateStrings, translateString...)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fields
 		}
 	}
 
-	return allTranslateStrings, nil
+	return fields
 }
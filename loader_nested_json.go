@@ -0,0 +1,173 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// LoadFromNestedJsonBytes loads a list of TranslateString from the
+// provided nested JSON bytes, the format used by many i18n tools
+// where the keys are grouped in objects:
+//
+//	{
+//	  "auth": {
+//	    "title": "Sign in",
+//	    "emails": {"One": "One email", "Many": "{{.Qty}} emails"}
+//	  }
+//	}
+//
+// The keys are joined with dots ("auth.title") and the texts are the
+// Default field. An object whose fields are all TranslateString fields
+// (like "One" and "Many") is a translation instead of a group of keys.
+func LoadFromNestedJsonBytes(
+	jsonBytes []byte,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeNestedJson, jsonBytes, defaultLoadOptions)
+}
+
+// LoadFromNestedJsonString loads a list of TranslateString
+// from the provided nested JSON string.
+func LoadFromNestedJsonString(
+	jsonString string,
+) (TranslateStrings, error) {
+	return LoadFromNestedJsonBytes([]byte(jsonString))
+}
+
+// LoadFromNestedJsonFiles loads a list of TranslateString from
+// one or multiple nested JSON files, allowing glob patterns
+// like "path/to/files/*.json".
+func LoadFromNestedJsonFiles(
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeNestedJson, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromNestedJsonFS loads a list of TranslateString from
+// one or multiple nested JSON files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.json".
+func LoadFromNestedJsonFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeNestedJson, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromNestedJsonBytesWithOptions is LoadFromNestedJsonBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromNestedJsonBytesWithOptions(
+	jsonBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeNestedJson, jsonBytes, options)
+}
+
+// LoadFromNestedJsonFilesWithOptions is LoadFromNestedJsonFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromNestedJsonFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeNestedJson, filesOrGlobs, options)
+}
+
+// LoadFromNestedJsonFSWithOptions is LoadFromNestedJsonFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromNestedJsonFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeNestedJson, fileSystem, filesOrGlobs, options)
+}
+
+func decodeNestedJson(jsonBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	if len(bytes.TrimSpace(jsonBytes)) == 0 {
+		return nil, nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		return nil, nil, jsonLoadError(jsonBytes, 0, "", err)
+	}
+
+	translateStrings := TranslateStrings{}
+	if err := flattenNestedJson("", root, &translateStrings); err != nil {
+		return nil, nil, err
+	}
+
+	// The objects don't keep the positions of their fields
+	return translateStrings, make([]Position, len(translateStrings)), nil
+}
+
+// flattenNestedJson adds the translations of an object, the
+// keys of the nested objects are prefixed with the parent key
+func flattenNestedJson(
+	prefix string,
+	object map[string]any,
+	translateStrings *TranslateStrings,
+) error {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch value := object[name].(type) {
+		case string:
+			*translateStrings = append(*translateStrings, TranslateString{Key: key, Default: value})
+		case map[string]any:
+			if !isNestedTranslation(value) {
+				if err := flattenNestedJson(key, value, translateStrings); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The translation is decoded again like in the other JSON files
+			fields, err := json.Marshal(value)
+			if err != nil {
+				return &LoadError{Key: key, Err: err}
+			}
+			var translateString TranslateString
+			if err := json.Unmarshal(fields, &translateString); err != nil {
+				return &LoadError{Key: key, Err: err}
+			}
+			translateString.Key = key
+			*translateStrings = append(*translateStrings, translateString)
+		default:
+			return &LoadError{
+				Key: key,
+				Err: fmt.Errorf("json: the value must be a text or an object"),
+			}
+		}
+	}
+
+	return nil
+}
+
+// isNestedTranslation checks if all the fields of the
+// object are TranslateString fields (other than the key)
+func isNestedTranslation(object map[string]any) bool {
+	if len(object) == 0 {
+		return false
+	}
+	for name := range object {
+		if !isVariantName(name) && !isExtraFieldName(name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadFromNestedJson(t *testing.T) {
+	t.Run("load texts and translations", func(t *testing.T) {
+		translateStrings, err := LoadFromNestedJsonString(`{
+			"auth": {
+				"title": "Sign in",
+				"emails": {"One": "One email", "Many": "{{.Qty}} emails", "MaxLength": 20},
+				"errors": {"password": "Wrong password"}
+			},
+			"hello": "Hello"
+		}`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := TranslateStrings{
+			{Key: "auth.emails", One: "One email", Many: "{{.Qty}} emails", MaxLength: 20},
+			{Key: "auth.errors.password", Default: "Wrong password"},
+			{Key: "auth.title", Default: "Sign in"},
+			{Key: "hello", Default: "Hello"},
+		}
+		if !reflect.DeepEqual(translateStrings, expected) {
+			t.Errorf("Unexpected result: %v", translateStrings)
+		}
+	})
+
+	t.Run("handle invalid values", func(t *testing.T) {
+		_, err := LoadFromNestedJsonString(`{"auth": {"title": 10}}`)
+		if err == nil || err.Error() != "goeasyi18n: key 'auth.title': json: the value must be a text or an object" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+
+		_, err = LoadFromNestedJsonString(`["hello"]`)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("load from files and fs", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"en.json": {Data: []byte(`{"home": {"title": "Home"}}`)},
+		}
+		translateStrings, err := LoadFromNestedJsonFS(fileSystem, "*.json")
+		if err != nil || len(translateStrings) != 1 || translateStrings[0].Key != "home.title" {
+			t.Errorf("Unexpected result: %v %v", translateStrings, err)
+		}
+	})
+}
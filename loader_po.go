@@ -0,0 +1,303 @@
+package goeasyi18n
+
+import (
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// LoadFromPoBytes loads a list of TranslateString
+// from the provided gettext PO bytes.
+//
+// The msgid is the key, the msgctxt is the Context and the
+// msgstr is the Default field. The plural forms (msgstr[N]) are
+// the plural variants in the order of poPluralVariants:
+//
+//	#. Greeting of the home page
+//	msgid "hello"
+//	msgstr "Hola {{.Name}}"
+//
+//	#, fuzzy
+//	msgid "emails"
+//	msgid_plural "emails"
+//	msgstr[0] "Un correo"
+//	msgstr[1] "{{.Qty}} correos"
+//
+// The extracted comments (#.) are the Description and the fuzzy flag
+// is the StatusFuzzy status. The header and the obsolete entries (#~)
+// are ignored.
+func LoadFromPoBytes(
+	poBytes []byte,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodePo, poBytes, defaultLoadOptions)
+}
+
+// LoadFromPoString loads a list of TranslateString
+// from the provided gettext PO string.
+func LoadFromPoString(
+	poString string,
+) (TranslateStrings, error) {
+	return LoadFromPoBytes([]byte(poString))
+}
+
+// LoadFromPoFiles loads a list of TranslateString from
+// one or multiple gettext PO files, allowing glob patterns
+// like "path/to/files/*.po".
+func LoadFromPoFiles(
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodePo, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromPoFS loads a list of TranslateString from
+// one or multiple gettext PO files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.po".
+func LoadFromPoFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodePo, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromPoBytesWithOptions is LoadFromPoBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPoBytesWithOptions(
+	poBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodePo, poBytes, options)
+}
+
+// LoadFromPoFilesWithOptions is LoadFromPoFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPoFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodePo, filesOrGlobs, options)
+}
+
+// LoadFromPoFSWithOptions is LoadFromPoFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPoFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodePo, fileSystem, filesOrGlobs, options)
+}
+
+// poPluralVariants are the plural variants of the msgstr[N]
+// forms, by the number of forms of the entries
+var poPluralVariants = [][]string{
+	{"Many"},
+	{"One", "Many"},
+	{"One", "Few", "Many"},
+	{"One", "Two", "Few", "Many"},
+	{"Zero", "One", "Two", "Few", "Many"},
+}
+
+// poEntry is an entry of a PO file while it is parsed
+type poEntry struct {
+	position    Position
+	comments    []string
+	fuzzy       bool
+	context     *string
+	id          *string
+	idPlural    *string
+	str         *string
+	pluralStrs  []string
+	lastKeyword string
+}
+
+func decodePo(poBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	src := strings.ReplaceAll(string(poBytes), "\r\n", "\n")
+	lines := strings.Split(strings.TrimPrefix(src, "\ufeff"), "\n")
+
+	translateStrings := TranslateStrings{}
+	positions := []Position{}
+	entry := &poEntry{}
+
+	flush := func() error {
+		if entry.id != nil && (*entry.id != "" || entry.context != nil) {
+			ts, err := entry.translateString()
+			if err != nil {
+				return &LoadError{Position: entry.position, Key: *entry.id, Err: err}
+			}
+			translateStrings = append(translateStrings, ts)
+			positions = append(positions, entry.position)
+		}
+		entry = &poEntry{}
+		return nil
+	}
+
+	for i, rawLine := range lines {
+		position := Position{Line: i + 1, Column: 1}
+		line := strings.TrimSpace(rawLine)
+
+		if line == "" {
+			if err := flush(); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		// A comment or a keyword after the msgstr starts a new entry
+		startsEntry := line[0] == '#' || strings.HasPrefix(line, "msgctxt") || strings.HasPrefix(line, "msgid ")
+		if startsEntry && (entry.str != nil || len(entry.pluralStrs) > 0) {
+			if err := flush(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if entry.position.Line == 0 {
+			entry.position = position
+		}
+
+		switch {
+		case strings.HasPrefix(line, "#~"):
+			// Obsolete entry
+		case strings.HasPrefix(line, "#."):
+			entry.comments = append(entry.comments, strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#,"):
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					entry.fuzzy = true
+				}
+			}
+		case line[0] == '#':
+			// Translator comments, references and previous strings
+		case line[0] == '"':
+			text, err := unquotePoString(line)
+			if err != nil {
+				return nil, nil, &LoadError{Position: position, Err: err}
+			}
+			if err := entry.appendText(text); err != nil {
+				return nil, nil, &LoadError{Position: position, Err: err}
+			}
+		default:
+			keyword, quoted, _ := strings.Cut(line, " ")
+			text, err := unquotePoString(strings.TrimSpace(quoted))
+			if err != nil {
+				return nil, nil, &LoadError{Position: position, Err: err}
+			}
+			if err := entry.setKeyword(keyword, text); err != nil {
+				return nil, nil, &LoadError{Position: position, Err: err}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, nil, err
+	}
+
+	return translateStrings, positions, nil
+}
+
+func (e *poEntry) setKeyword(keyword string, text string) error {
+	e.lastKeyword = keyword
+	switch {
+	case keyword == "msgctxt":
+		e.context = &text
+	case keyword == "msgid":
+		e.id = &text
+	case keyword == "msgid_plural":
+		e.idPlural = &text
+	case keyword == "msgstr":
+		e.str = &text
+	case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+		index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+		if err != nil || index != len(e.pluralStrs) {
+			return fmt.Errorf("po: unexpected plural form '%s'", keyword)
+		}
+		e.pluralStrs = append(e.pluralStrs, text)
+	default:
+		return fmt.Errorf("po: unknown keyword '%s'", keyword)
+	}
+	return nil
+}
+
+// appendText appends a continuation string to the last keyword
+func (e *poEntry) appendText(text string) error {
+	switch {
+	case e.lastKeyword == "msgctxt" && e.context != nil:
+		*e.context += text
+	case e.lastKeyword == "msgid" && e.id != nil:
+		*e.id += text
+	case e.lastKeyword == "msgid_plural" && e.idPlural != nil:
+		*e.idPlural += text
+	case e.lastKeyword == "msgstr" && e.str != nil:
+		*e.str += text
+	case strings.HasPrefix(e.lastKeyword, "msgstr[") && len(e.pluralStrs) > 0:
+		e.pluralStrs[len(e.pluralStrs)-1] += text
+	default:
+		return fmt.Errorf("po: unexpected string")
+	}
+	return nil
+}
+
+func (e *poEntry) translateString() (TranslateString, error) {
+	ts := TranslateString{
+		Key:         *e.id,
+		Description: strings.Join(e.comments, "\n"),
+	}
+	if e.context != nil {
+		ts.Context = *e.context
+	}
+	if e.fuzzy {
+		ts.Status = StatusFuzzy
+	}
+	if e.str != nil {
+		ts.Default = *e.str
+	}
+
+	if len(e.pluralStrs) > len(poPluralVariants) {
+		return ts, fmt.Errorf("po: more than %d plural forms are not supported", len(poPluralVariants))
+	}
+	if len(e.pluralStrs) > 0 {
+		for i, variant := range poPluralVariants[len(e.pluralStrs)-1] {
+			if err := setTranslateStringField(&ts, variant, e.pluralStrs[i]); err != nil {
+				return ts, err
+			}
+		}
+	}
+
+	return ts, nil
+}
+
+// unquotePoString unquotes a C string of a PO file
+func unquotePoString(quoted string) (string, error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return "", fmt.Errorf("po: invalid string %s", quoted)
+	}
+
+	var sb strings.Builder
+	s := quoted[1 : len(quoted)-1]
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'a':
+			sb.WriteByte('\a')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'v':
+			sb.WriteByte('\v')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,90 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadFromPo(t *testing.T) {
+	t.Run("load entries, contexts and plurals", func(t *testing.T) {
+		translateStrings, err := LoadFromPoString(`# Spanish translations
+msgid ""
+msgstr ""
+"Language: es\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+#. Greeting of the home page
+#: main.go:10
+msgid "hello"
+msgstr "Hola {{.Name}}"
+
+#, fuzzy, c-format
+msgctxt "verb"
+msgid "open"
+msgstr "Abrir"
+
+msgid "emails"
+msgid_plural "emails"
+msgstr[0] "Un correo"
+msgstr[1] "{{.Qty}} "
+"correos"
+
+msgid ""
+"multi\n"
+"line"
+msgstr "Varias\n"
+"líneas \"escapadas\""
+
+#~ msgid "old"
+#~ msgstr "Viejo"
+`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := TranslateStrings{
+			{Key: "hello", Default: "Hola {{.Name}}", Description: "Greeting of the home page"},
+			{Key: "open", Context: "verb", Default: "Abrir", Status: StatusFuzzy},
+			{Key: "emails", One: "Un correo", Many: "{{.Qty}} correos"},
+			{Key: "multi\nline", Default: "Varias\nlíneas \"escapadas\""},
+		}
+		if !reflect.DeepEqual(translateStrings, expected) {
+			t.Errorf("Unexpected result: %#v", translateStrings)
+		}
+	})
+
+	t.Run("map the number of plural forms to the variants", func(t *testing.T) {
+		translateStrings, err := LoadFromPoString(`msgid "apples"
+msgid_plural "apples"
+msgstr[0] "jabłko"
+msgstr[1] "jabłka"
+msgstr[2] "jabłek"
+`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ts := translateStrings[0]
+		if ts.One != "jabłko" || ts.Few != "jabłka" || ts.Many != "jabłek" {
+			t.Errorf("Unexpected result: %#v", ts)
+		}
+	})
+
+	t.Run("handle incorrect po", func(t *testing.T) {
+		_, err := LoadFromPoString("msgid \"hello\"\nmsgstr \"Hola\n")
+		if err == nil || err.Error() != "goeasyi18n: line 2, column 1: po: invalid string \"Hola" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+
+		_, err = LoadFromPoString("msgid \"hello\"\nmsgtext \"Hola\"\n")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle duplicated keys", func(t *testing.T) {
+		_, err := LoadFromPoBytesWithOptions([]byte("msgid \"hello\"\nmsgstr \"Hola\"\n\nmsgid \"hello\"\nmsgstr \"Buenas\"\n"), LoadOptions{})
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package goeasyi18n
+
+import (
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LoadFromPropertiesBytes loads a list of TranslateString
+// from the provided Java .properties bytes.
+//
+// Every property is a translation, the property name is the key
+// and the value is the Default field, the other fields of the
+// TranslateString can be set adding the field name as a suffix:
+//
+//	hello = Hello
+//	hello_emails.One = You have one email
+//	hello_emails.Many = You have {{.EmailQty}} emails
+//
+// The same key can be repeated with a different context:
+//
+//	open.Context = verb
+//	open = Open
+//	open.Context = status
+//	open = Opened
+//
+// The metadata for the translators is set in the same way,
+// the Tags are comma separated:
+//
+//	hello.Description = Greeting of the home page
+//	hello.MaxLength = 20
+//	hello.Tags = home, greeting
+//
+// A suffix that misspells a field (like "hello.Mnay") is an error
+// instead of a new key, and with the Strict option any unknown
+// suffix that starts with an uppercase letter is an error too.
+func LoadFromPropertiesBytes(
+	propertiesBytes []byte,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeProperties, propertiesBytes, defaultLoadOptions)
+}
+
+// LoadFromPropertiesString loads a list of TranslateString
+// from the provided Java .properties string.
+func LoadFromPropertiesString(
+	propertiesString string,
+) (TranslateStrings, error) {
+	return LoadFromPropertiesBytes([]byte(propertiesString))
+}
+
+// LoadFromPropertiesFiles loads a list of TranslateString from
+// one or multiple Java .properties files, allowing glob patterns
+// like "path/to/files/*.properties".
+func LoadFromPropertiesFiles(
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeProperties, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromPropertiesFS loads a list of TranslateString from
+// one or multiple Java .properties files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.properties".
+func LoadFromPropertiesFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeProperties, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromPropertiesBytesWithOptions is LoadFromPropertiesBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPropertiesBytesWithOptions(
+	propertiesBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeProperties, propertiesBytes, options)
+}
+
+// LoadFromPropertiesFilesWithOptions is LoadFromPropertiesFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPropertiesFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeProperties, filesOrGlobs, options)
+}
+
+// LoadFromPropertiesFSWithOptions is LoadFromPropertiesFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromPropertiesFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeProperties, fileSystem, filesOrGlobs, options)
+}
+
+func decodeProperties(propertiesBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	properties, err := parseProperties(string(propertiesBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	translateStrings := TranslateStrings{}
+	positions := []Position{}
+	indexes := map[string]int{}
+
+	for _, property := range properties {
+		key := property.name
+		variant := "Default"
+		if dot := strings.LastIndex(key, "."); dot >= 0 && isPropertyField(key[dot+1:]) {
+			key, variant = key[:dot], key[dot+1:]
+		} else if dot >= 0 && isUpperStart(key[dot+1:]) {
+			// The misspelled variants (like "Mnay") are always rejected,
+			// and in strict mode any suffix that looks like a variant
+			if strict || isPropertyFieldTypo(key[dot+1:]) {
+				return nil, nil, &LoadError{
+					Position: property.position,
+					Key:      key[:dot],
+					Err:      unknownFieldError(key[dot+1:]),
+				}
+			}
+		}
+
+		// A repeated property starts a new translation with the same
+		// key, so it is handled like any other duplicated key
+		index, exists := indexes[key]
+		if !exists || !reflect.ValueOf(translateStrings[index]).FieldByName(variant).IsZero() {
+			index = len(translateStrings)
+			indexes[key] = index
+			translateStrings = append(translateStrings, TranslateString{Key: key})
+			positions = append(positions, property.position)
+		}
+
+		err := setTranslateStringField(&translateStrings[index], variant, property.value)
+		if err != nil {
+			return nil, nil, &LoadError{Position: property.position, Key: key, Err: err}
+		}
+	}
+
+	return translateStrings, positions, nil
+}
+
+// property is a single name-value pair of a .properties file
+type property struct {
+	name     string
+	value    string
+	position Position
+}
+
+// isPropertyFieldTypo checks if a suffix misspells a variant or
+// metadata field, with the same letters in another order or in
+// another case (like "Mnay" or "MANY")
+func isPropertyFieldTypo(name string) bool {
+	if isPropertyField(name) {
+		return false
+	}
+	for _, field := range append(append([]string{}, variantNames...), extraFieldNames...) {
+		if strings.EqualFold(name, field) || sortedLetters(name) == sortedLetters(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedLetters returns the lowercase letters of a name sorted
+func sortedLetters(name string) string {
+	letters := []rune(strings.ToLower(name))
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
+
+// isPropertyField checks if the name is one of the TranslateString
+// fields that can be set with a property name suffix
+func isPropertyField(name string) bool {
+	return isVariantName(name) || isExtraFieldName(name)
+}
+
+func isUpperStart(s string) bool {
+	return s != "" && s[0] >= 'A' && s[0] <= 'Z'
+}
+
+// parseProperties parses the contents of a .properties file following
+// the java.util.Properties format: comments starting with "#" or "!",
+// "=", ":" or whitespace as separators, backslash line continuations
+// and escape sequences (including \uXXXX)
+func parseProperties(src string) ([]property, error) {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	lines := strings.Split(src, "\n")
+
+	var properties []property
+
+	for i := 0; i < len(lines); i++ {
+		position := Position{
+			Line:   i + 1,
+			Column: len(lines[i]) - len(strings.TrimLeft(lines[i], " \t\f")) + 1,
+		}
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Join the continuation lines
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		// Find the separator between the name and the value
+		separator := len(line)
+		for j := 0; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+				continue
+			}
+			if line[j] == '=' || line[j] == ':' || line[j] == ' ' || line[j] == '\t' || line[j] == '\f' {
+				separator = j
+				break
+			}
+		}
+
+		rawName, rawValue := line[:separator], ""
+		if separator < len(line) {
+			rawValue = line[separator+1:]
+			if line[separator] != '=' && line[separator] != ':' {
+				rawValue = strings.TrimLeft(rawValue, " \t\f")
+				if rawValue != "" && (rawValue[0] == '=' || rawValue[0] == ':') {
+					rawValue = rawValue[1:]
+				}
+			}
+			rawValue = strings.TrimLeft(rawValue, " \t\f")
+		}
+
+		name, err := unescapeProperty(rawName)
+		if err != nil {
+			return nil, &LoadError{Position: position, Err: err}
+		}
+		value, err := unescapeProperty(rawValue)
+		if err != nil {
+			return nil, &LoadError{Position: position, Key: name, Err: err}
+		}
+
+		properties = append(properties, property{
+			name:     name,
+			value:    value,
+			position: position,
+		})
+	}
+
+	return properties, nil
+}
+
+// endsWithContinuation checks if the line ends with an odd
+// number of backslashes, that means that it continues in the
+// next line
+func endsWithContinuation(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("properties: invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("properties: invalid unicode escape '\\u%s'", s[i+1:i+5])
+			}
+			sb.WriteRune(rune(code))
+			i += 4
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,220 @@
+package goeasyi18n
+
+import (
+	"embed"
+	"testing"
+)
+
+func TestLoadFromPropertiesBytes(t *testing.T) {
+	bytes := []byte("hello = Hello\n")
+	strings, err := LoadFromPropertiesBytes(bytes)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(strings) != 1 || strings[0].Key != "hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+	if strings[0].Default != "Hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+}
+
+func TestLoadFromPropertiesString(t *testing.T) {
+	strings, err := LoadFromPropertiesString("hello = Hello\n")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(strings) != 1 || strings[0].Key != "hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+	if strings[0].Default != "Hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+}
+
+func TestLoadFromPropertiesFiles(t *testing.T) {
+	t.Run("load single file", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFiles("./testfiles/test1.properties")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 1 || strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Default != "Hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load multiple files", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFiles(
+			"./testfiles/test1.properties",
+			"./testfiles/test2.properties",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[1].Key != "world" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load with glob pattern", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFiles("./testfiles/test*.properties")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("handle incorrect properties", func(t *testing.T) {
+		_, err := LoadFromPropertiesFiles("./testfiles/incorrect.properties")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromPropertiesFiles("./testfiles/nomatch*.properties")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+//go:embed testfiles/*
+var propertiesTestFiles embed.FS
+
+func TestLoadFromPropertiesFS(t *testing.T) {
+	t.Run("load single file", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFS(
+			propertiesTestFiles,
+			"testfiles/test1.properties",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 1 || strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Default != "Hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load multiple files", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFS(
+			propertiesTestFiles,
+			"testfiles/test1.properties",
+			"testfiles/test2.properties",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[1].Key != "world" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load with glob pattern", func(t *testing.T) {
+		strings, err := LoadFromPropertiesFS(
+			propertiesTestFiles,
+			"testfiles/test*.properties",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("handle incorrect properties", func(t *testing.T) {
+		_, err := LoadFromPropertiesFS(
+			propertiesTestFiles,
+			"testfiles/incorrect.properties",
+		)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromPropertiesFS(
+			propertiesTestFiles,
+			"testfiles/nomatch*.properties",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoadFromPropertiesSyntax(t *testing.T) {
+	t.Run("load variants, separators, continuations and escapes", func(t *testing.T) {
+		strings, err := LoadFromPropertiesString(`
+# Comments are ignored
+! This one too
+hello_emails.One = You have one email
+hello_emails.Many: You have {{.EmailQty}} emails
+welcome Welcome \
+    to the app
+auth.title=Sign in\tnow ¡
+key\ with\ spaces = value
+`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(strings) != 4 {
+			t.Fatalf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello_emails" ||
+			strings[0].One != "You have one email" ||
+			strings[0].Many != "You have {{.EmailQty}} emails" {
+			t.Errorf("Unexpected result: %v", strings[0])
+		}
+		if strings[1].Key != "welcome" || strings[1].Default != "Welcome to the app" {
+			t.Errorf("Unexpected result: %v", strings[1])
+		}
+		if strings[2].Key != "auth.title" || strings[2].Default != "Sign in\tnow ¡" {
+			t.Errorf("Unexpected result: %v", strings[2])
+		}
+		if strings[3].Key != "key with spaces" || strings[3].Default != "value" {
+			t.Errorf("Unexpected result: %v", strings[3])
+		}
+	})
+
+	t.Run("handle misspelled variants", func(t *testing.T) {
+		for _, properties := range []string{"greeting.Mnay = 2\n", "greeting.MANY = 2\n", "greeting.Defualt = Hi\n"} {
+			if _, err := LoadFromPropertiesString(properties); err == nil {
+				t.Errorf("Expected error, got nil: %s", properties)
+			}
+		}
+
+		strings, err := LoadFromPropertiesString("errors.NotFound = Not found\n")
+		if err != nil || len(strings) != 1 || strings[0].Key != "errors.NotFound" {
+			t.Errorf("Unexpected result: %v %v", strings, err)
+		}
+	})
+
+	t.Run("handle repeated properties", func(t *testing.T) {
+		_, err := LoadFromPropertiesBytesWithOptions([]byte("hello = Hello\nhello = Again\n"), LoadOptions{})
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,472 @@
+package goeasyi18n
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	t.Run("detect duplicated keys within a file with their positions", func(t *testing.T) {
+		_, err := LoadFromJsonBytesWithOptions([]byte(`[
+  {"Key": "hello", "Default": "Hello"},
+  {"Key": "world", "Default": "World"},
+  {"Key": "hello", "Default": "Hello again"}
+]`), LoadOptions{})
+
+		var duplicatesError *DuplicateKeysError
+		if !errors.As(err, &duplicatesError) {
+			t.Fatalf("expected a DuplicateKeysError; got %v", err)
+		}
+		if len(duplicatesError.Duplicates) != 1 {
+			t.Fatalf("Unexpected result: %v", duplicatesError.Duplicates)
+		}
+
+		duplicate := duplicatesError.Duplicates[0]
+		if duplicate.Key != "hello" || len(duplicate.Positions) != 2 {
+			t.Fatalf("Unexpected result: %v", duplicate)
+		}
+		if duplicate.Positions[0].Line != 2 || duplicate.Positions[1].Line != 4 {
+			t.Errorf("Unexpected positions: %v", duplicate.Positions)
+		}
+
+		expected := "goeasyi18n: the key 'hello' is defined more than once (line 2, column 3; line 4, column 3)"
+		if err.Error() != expected {
+			t.Errorf("expected %s; got %s", expected, err.Error())
+		}
+	})
+
+	t.Run("detect duplicated keys across files", func(t *testing.T) {
+		_, err := LoadFromYamlFilesWithOptions(
+			LoadOptions{},
+			"./testfiles/test1.yaml",
+			"./testfiles/test1.yaml",
+		)
+
+		expected := "goeasyi18n: the key 'hello' is defined more than once " +
+			"(./testfiles/test1.yaml:1:3; ./testfiles/test1.yaml:1:3)"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %s; got %v", expected, err)
+		}
+	})
+
+	t.Run("detect duplicated keys in every format", func(t *testing.T) {
+		options := LoadOptions{}
+		_, errToml := LoadFromTomlFilesWithOptions(options, "./testfiles/test1.toml", "./testfiles/test1.toml")
+		_, errProperties := LoadFromPropertiesBytesWithOptions([]byte("hello = Hello\nhello = Again\n"), options)
+		_, errCsv := LoadFromCsvBytesWithOptions([]byte("Key,en\nhello,Hello\nhello,Again\n"), options)
+
+		for _, err := range []error{errToml, errProperties, errCsv} {
+			var duplicatesError *DuplicateKeysError
+			if !errors.As(err, &duplicatesError) {
+				t.Errorf("expected a DuplicateKeysError; got %v", err)
+			}
+		}
+	})
+
+	t.Run("keep the duplicated keys in the loaders without options", func(t *testing.T) {
+		translateStrings, err := LoadFromJsonString(`[
+  {"Key": "hello", "Default": "Hello"},
+  {"Key": "hello", "Default": "Hello again"}
+]`)
+		if err != nil || len(translateStrings) != 2 {
+			t.Errorf("Unexpected result: %v %v", translateStrings, err)
+		}
+
+		i18n := NewI18n()
+		i18n.AddLanguage("en", translateStrings)
+		if got := i18n.T("en", "hello"); got != "Hello" {
+			t.Errorf("expected %s; got %s", "Hello", got)
+		}
+	})
+
+	t.Run("apply the policy of the options", func(t *testing.T) {
+		data := []byte("- Key: hello\n  Default: First\n- Key: hello\n  Default: Last\n")
+
+		tests := []struct {
+			policy   DuplicateKeyPolicy
+			expected string
+		}{
+			{DuplicateKeyPolicyFirstWins, "First"},
+			{DuplicateKeyPolicyLastWins, "Last"},
+		}
+
+		for _, test := range tests {
+			translateStrings, err := LoadFromYamlBytesWithOptions(data, LoadOptions{DuplicateKeyPolicy: test.policy})
+			if err != nil || len(translateStrings) != 1 || translateStrings[0].Default != test.expected {
+				t.Errorf("Unexpected result: %v %v", translateStrings, err)
+			}
+		}
+	})
+
+	t.Run("apply the policy of the config in LoadFS", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"en/a.json": {Data: []byte(`[{"Key": "hello", "Default": "First"}]`)},
+			"en/b.yaml": {Data: []byte("- Key: hello\n  Default: Last\n")},
+		}
+
+		tests := []struct {
+			policy   DuplicateKeyPolicy
+			expected string
+		}{
+			{DuplicateKeyPolicyFirstWins, "First"},
+			{DuplicateKeyPolicyLastWins, "Last"},
+		}
+
+		for _, test := range tests {
+			i18n := NewI18n(Config{DuplicateKeyPolicy: test.policy})
+			err := i18n.LoadFS(fileSystem, ".")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := i18n.Translate("en", "hello")
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+
+		i18n := NewI18n()
+		err := i18n.LoadFS(fileSystem, ".")
+		expected := "goeasyi18n: the key 'hello' is defined more than once in the language 'en' (en/a.json:1:2; en/b.yaml:1:3)"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %s; got %v", expected, err)
+		}
+	})
+
+	t.Run("report the duplicates of all the languages in LoadFS", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"en.yaml": {Data: []byte("- Key: hello\n- Key: hello\n")},
+			"es.yaml": {Data: []byte("- Key: bye\n- Key: bye\n- Key: hi\n- Key: hi\n")},
+		}
+
+		err := NewI18n().LoadFS(fileSystem, ".")
+		var duplicatesError *DuplicateKeysError
+		if !errors.As(err, &duplicatesError) {
+			t.Fatalf("expected a DuplicateKeysError; got %v", err)
+		}
+		if len(duplicatesError.Duplicates) != 3 {
+			t.Errorf("Unexpected result: %v", duplicatesError.Duplicates)
+		}
+	})
+
+	t.Run("report the duplicates of all the languages in order", func(t *testing.T) {
+		csv := "Key,fr,en,es\nhello,Salut,Hi,Hola\nhello,Bonjour,Hello,Buenas\n"
+		expected := "goeasyi18n: the key 'hello' is defined more than once in the language 'en' (line 2, column 1; line 3, column 1)\n" +
+			"goeasyi18n: the key 'hello' is defined more than once in the language 'es' (line 2, column 1; line 3, column 1)\n" +
+			"goeasyi18n: the key 'hello' is defined more than once in the language 'fr' (line 2, column 1; line 3, column 1)"
+		for i := 0; i < 10; i++ {
+			_, err := LoadFromCsvBytesWithOptions([]byte(csv), LoadOptions{})
+			var duplicatesError *DuplicateKeysError
+			if !errors.As(err, &duplicatesError) {
+				t.Fatalf("expected a DuplicateKeysError; got %v", err)
+			}
+			if err.Error() != expected {
+				t.Fatalf("expected %s; got %s", expected, err.Error())
+			}
+			if duplicatesError.Duplicates[0].Language != "en" {
+				t.Errorf("expected %s; got %s", "en", duplicatesError.Duplicates[0].Language)
+			}
+		}
+	})
+}
+
+func TestLoadError(t *testing.T) {
+	t.Run("errors have the position and the key of the translation", func(t *testing.T) {
+		_, errJson := LoadFromJsonString("[\n  {\"Key\": \"hello\", \"Default\": 5}\n]")
+		_, errYaml := LoadFromYamlString("- Key: hello\n  Default: [1]\n")
+		_, errToml := LoadFromTomlString("[hello]\nDefault = [1]\n")
+		_, errProperties := LoadFromPropertiesString("\n  hello = \\u12")
+
+		tests := []struct {
+			err      error
+			line     int
+			column   int
+			expected string
+		}{
+			{errJson, 2, 32, "goeasyi18n: line 2, column 32: key 'hello': json: cannot unmarshal number into Go struct field TranslateString.Default of type string"},
+			{errYaml, 2, 3, "goeasyi18n: line 2, column 3: key 'hello': json: cannot unmarshal array into Go struct field TranslateString.Default of type string"},
+			{errToml, 2, 1, "goeasyi18n: line 2, column 1: key 'hello': json: cannot unmarshal array into Go struct field TranslateString.Default of type string"},
+			{errProperties, 2, 3, "goeasyi18n: line 2, column 3: key 'hello': properties: invalid unicode escape"},
+		}
+
+		for _, test := range tests {
+			var loadError *LoadError
+			if !errors.As(test.err, &loadError) {
+				t.Fatalf("expected a LoadError; got %v", test.err)
+			}
+			if loadError.Key != "hello" || loadError.Line != test.line || loadError.Column != test.column {
+				t.Errorf("Unexpected result: %+v", loadError)
+			}
+			if loadError.Error() != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, loadError.Error())
+			}
+		}
+	})
+
+	t.Run("syntax errors have the position", func(t *testing.T) {
+		_, errJson := LoadFromJsonString("[\n  {\"Key\": \"hello\",, }\n]")
+		_, errYaml := LoadFromYamlString("- Key: hello\n  Default: x\n - b")
+		_, errToml := LoadFromTomlString("[hello]\nDefault = 'x")
+		_, errCsv := LoadFromCsvString("Key,en\n\"a,b\n")
+		_, errFluent := LoadFromFluentString("hello = Hello\nworld = {")
+
+		tests := []struct {
+			err  error
+			line int
+		}{
+			{errJson, 2},
+			{errYaml, 2},
+			{errToml, 2},
+			{errCsv, 2},
+			{errFluent, 2},
+		}
+
+		for _, test := range tests {
+			var loadError *LoadError
+			if !errors.As(test.err, &loadError) {
+				t.Fatalf("expected a LoadError; got %v", test.err)
+			}
+			if loadError.Line != test.line {
+				t.Errorf("expected line %d; got %+v", test.line, loadError)
+			}
+		}
+	})
+
+	t.Run("truncated arrays are errors", func(t *testing.T) {
+		tests := []string{"[ ", "[\n {\"Key\":\"a\"},\n ", "[\n {\"Key\":\"a\"}", "[{\"Key\":"}
+		for _, test := range tests {
+			var loadError *LoadError
+			if _, err := LoadFromJsonString(test); !errors.As(err, &loadError) {
+				t.Errorf("expected a LoadError for %q; got %v", test, err)
+			}
+		}
+	})
+
+	t.Run("errors from files have the file name", func(t *testing.T) {
+		_, err := LoadFromJsonFiles("./testfiles/incorrect.json")
+
+		var loadError *LoadError
+		if !errors.As(err, &loadError) {
+			t.Fatalf("expected a LoadError; got %v", err)
+		}
+		if loadError.File != "./testfiles/incorrect.json" || loadError.Line != 1 {
+			t.Errorf("Unexpected result: %+v", loadError)
+		}
+	})
+}
+
+func TestPositionTracker(t *testing.T) {
+	data := []byte("héllo\n\nwörld\n  end")
+	tests := []struct {
+		offset int
+		line   int
+		column int
+	}{
+		{0, 1, 1},
+		{3, 1, 3},
+		{7, 2, 1},
+		{11, 3, 3},
+		{1, 1, 2},
+		{17, 4, 3},
+		{100, 4, 6},
+	}
+
+	tracker := newPositionTracker(data)
+	for _, test := range tests {
+		got := tracker.at(test.offset)
+		if got.Line != test.line || got.Column != test.column {
+			t.Errorf("Unexpected result for the offset %d: %+v", test.offset, got)
+		}
+	}
+}
+
+func TestStrictLoading(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"en.json":       {Data: []byte("[\n  {\"Key\": \"hello\", \"Mnay\": \"x\"}\n]")},
+		"es.yaml":       {Data: []byte("- Key: hello\n  Mnay: x\n")},
+		"fr.toml":       {Data: []byte("[hello]\nMnay = 'x'\n")},
+		"de.properties": {Data: []byte("hello.Mnay = x\n")},
+	}
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"en.json", "goeasyi18n: en.json:2:20: key 'hello': unknown field 'Mnay'"},
+		{"es.yaml", "goeasyi18n: es.yaml:2:3: key 'hello': unknown field 'Mnay'"},
+		{"fr.toml", "goeasyi18n: fr.toml:2:1: key 'hello': unknown field 'Mnay'"},
+		{"de.properties", "goeasyi18n: de.properties:1:1: key 'hello': unknown field 'Mnay'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			file := fstest.MapFS{test.file: fileSystem[test.file]}
+
+			err := NewI18n(Config{StrictLoading: true}).LoadFS(file, ".")
+			if err == nil || err.Error() != test.expected {
+				t.Errorf("expected %s; got %v", test.expected, err)
+			}
+
+			// The properties would silently get a "hello.Mnay" key
+			err = NewI18n().LoadFS(file, ".")
+			if test.file == "de.properties" {
+				if err == nil || err.Error() != test.expected {
+					t.Errorf("expected %s; got %v", test.expected, err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error without strict mode: %v", err)
+			}
+		})
+	}
+
+	t.Run("reject the unknown fields in the loaders with options", func(t *testing.T) {
+		data := []byte("- Key: hello\n  Mnay: x\n")
+
+		_, err := LoadFromYamlBytesWithOptions(data, LoadOptions{Strict: true})
+		expected := "goeasyi18n: line 2, column 3: key 'hello': unknown field 'Mnay'"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %s; got %v", expected, err)
+		}
+
+		_, err = LoadFromYamlBytes(data)
+		if err != nil {
+			t.Errorf("Unexpected error without strict mode: %v", err)
+		}
+	})
+
+	t.Run("known fields are case insensitive", func(t *testing.T) {
+		file := fstest.MapFS{"en.yaml": {Data: []byte("- key: hello\n  many: x\n")}}
+		err := NewI18n(Config{StrictLoading: true}).LoadFS(file, ".")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoadContext(t *testing.T) {
+	expected := TranslateStrings{
+		{Key: "open", Context: "verb", Default: "Abrir"},
+		{Key: "open", Context: "status", Default: "Abierto"},
+	}
+
+	fromJson, errJson := LoadFromJsonString(`[
+		{"Key": "open", "Context": "verb", "Default": "Abrir"},
+		{"Key": "open", "Context": "status", "Default": "Abierto"}
+	]`)
+	fromYaml, errYaml := LoadFromYamlString("- Key: open\n  Context: verb\n  Default: Abrir\n- Key: open\n  Context: status\n  Default: Abierto\n")
+	fromToml, errToml := LoadFromTomlString("[[open]]\nContext = 'verb'\nDefault = 'Abrir'\n\n[[open]]\nContext = 'status'\nDefault = 'Abierto'\n")
+	fromProperties, errProperties := LoadFromPropertiesString("open.Context = verb\nopen = Abrir\nopen.Context = status\nopen = Abierto\n")
+	fromCsv, errCsv := LoadFromCsvString("Key,Context,es\nopen,verb,Abrir\nopen,status,Abierto\n")
+
+	tests := []struct {
+		name   string
+		loaded TranslateStrings
+		err    error
+	}{
+		{"json", fromJson, errJson},
+		{"yaml", fromYaml, errYaml},
+		{"toml", fromToml, errToml},
+		{"properties", fromProperties, errProperties},
+		{"csv", fromCsv["es"], errCsv},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err != nil {
+				t.Fatalf("Unexpected error: %v", test.err)
+			}
+			if !reflect.DeepEqual(test.loaded, expected) {
+				t.Errorf("Unexpected result: %v", test.loaded)
+			}
+		})
+	}
+
+	t.Run("the same key and context is a duplicate", func(t *testing.T) {
+		_, err := LoadFromCsvBytesWithOptions([]byte("Key,Context,es\nopen,verb,Abrir\nopen,verb,Abre\n"), LoadOptions{})
+		expectedError := "goeasyi18n: the key 'open' with the context 'verb' is defined more than once in the language 'es' (line 2, column 1; line 3, column 1)"
+		if err == nil || err.Error() != expectedError {
+			t.Errorf("expected %s; got %v", expectedError, err)
+		}
+	})
+}
+
+func TestLoadMetadata(t *testing.T) {
+	expected := TranslateStrings{{
+		Key:           "save",
+		Default:       "Save",
+		Description:   "Button of the settings form",
+		MaxLength:     10,
+		Tags:          []string{"settings", "button"},
+		ScreenshotRef: "screenshots/settings.png",
+	}}
+
+	fromJson, errJson := LoadFromJsonString(`[{
+		"Key": "save",
+		"Default": "Save",
+		"Description": "Button of the settings form",
+		"MaxLength": 10,
+		"Tags": ["settings", "button"],
+		"ScreenshotRef": "screenshots/settings.png"
+	}]`)
+	fromYaml, errYaml := LoadFromYamlString(`- Key: save
+  Default: Save
+  Description: Button of the settings form
+  MaxLength: 10
+  Tags: [settings, button]
+  ScreenshotRef: screenshots/settings.png
+`)
+	fromToml, errToml := LoadFromTomlString(`[save]
+Default = "Save"
+Description = "Button of the settings form"
+MaxLength = 10
+Tags = ["settings", "button"]
+ScreenshotRef = "screenshots/settings.png"
+`)
+	fromProperties, errProperties := LoadFromPropertiesString(`save = Save
+save.Description = Button of the settings form
+save.MaxLength = 10
+save.Tags = settings, button
+save.ScreenshotRef = screenshots/settings.png
+`)
+	fromCsv, errCsv := LoadFromCsvString(`Key,Description,MaxLength,Tags,ScreenshotRef,en
+save,Button of the settings form,10,"settings, button",screenshots/settings.png,Save
+`)
+
+	tests := []struct {
+		name   string
+		loaded TranslateStrings
+		err    error
+	}{
+		{"json", fromJson, errJson},
+		{"yaml", fromYaml, errYaml},
+		{"toml", fromToml, errToml},
+		{"properties", fromProperties, errProperties},
+		{"csv", fromCsv["en"], errCsv},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err != nil {
+				t.Fatalf("Unexpected error: %v", test.err)
+			}
+			if !reflect.DeepEqual(test.loaded, expected) {
+				t.Errorf("Unexpected result: %#v", test.loaded)
+			}
+		})
+	}
+
+	t.Run("MaxLength must be a number", func(t *testing.T) {
+		_, errProperties := LoadFromPropertiesString("save.MaxLength = ten")
+		_, errCsv := LoadFromCsvString("Key,MaxLength,en\nsave,ten,Save\n")
+
+		expectedProperties := "goeasyi18n: line 1, column 1: key 'save': the field 'MaxLength' must be a number"
+		if errProperties == nil || errProperties.Error() != expectedProperties {
+			t.Errorf("expected %s; got %v", expectedProperties, errProperties)
+		}
+		expectedCsv := "goeasyi18n: line 2, column 6: key 'save': csv: the field 'MaxLength' must be a number"
+		if errCsv == nil || errCsv.Error() != expectedCsv {
+			t.Errorf("expected %s; got %v", expectedCsv, errCsv)
+		}
+	})
+}
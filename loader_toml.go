@@ -0,0 +1,561 @@
+package goeasyi18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LoadFromTomlBytes loads a list of TranslateString
+// from the provided TOML bytes.
+//
+// Every table is a translation, the table name is the key
+// and its values are the fields of the TranslateString:
+//
+//	[hello_emails]
+//	One = "You have one email"
+//	Many = "You have {{.EmailQty}} emails"
+//
+// Nested tables like [auth.title] are flattened into
+// dotted keys ("auth.title"). The same key can be defined
+// with multiple contexts using an array of tables:
+//
+//	[[open]]
+//	Context = "verb"
+//	Default = "Open"
+//
+//	[[open]]
+//	Context = "status"
+//	Default = "Opened"
+func LoadFromTomlBytes(
+	tomlBytes []byte,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeToml, tomlBytes, defaultLoadOptions)
+}
+
+// LoadFromTomlString loads a list of TranslateString
+// from the provided TOML string.
+func LoadFromTomlString(
+	tomlString string,
+) (TranslateStrings, error) {
+	return LoadFromTomlBytes([]byte(tomlString))
+}
+
+// LoadFromTomlFiles loads a list of TranslateString from
+// one or multiple TOML files, allowing glob patterns
+// like "path/to/files/*.toml".
+func LoadFromTomlFiles(
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeToml, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromTomlFS loads a list of TranslateString from
+// one or multiple TOML files located within a provided
+// filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.toml".
+func LoadFromTomlFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeToml, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromTomlBytesWithOptions is LoadFromTomlBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromTomlBytesWithOptions(
+	tomlBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeToml, tomlBytes, options)
+}
+
+// LoadFromTomlFilesWithOptions is LoadFromTomlFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromTomlFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeToml, filesOrGlobs, options)
+}
+
+// LoadFromTomlFSWithOptions is LoadFromTomlFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromTomlFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeToml, fileSystem, filesOrGlobs, options)
+}
+
+func decodeToml(tomlBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	// This function uses a bridge, it converts TOML to JSON before
+	// parsing it as a TranslateString to avoid inconsistencies
+
+	tables, err := parseToml(tomlBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	translateStrings := make(TranslateStrings, 0, len(tables))
+	positions := make([]Position, 0, len(tables))
+
+	for _, table := range tables {
+		entry := map[string]any{"Key": table.name}
+		for k, v := range table.values {
+			if strict && !isTranslateStringField(k) {
+				return nil, nil, &LoadError{
+					Position: table.keyPositions[k],
+					Key:      table.name,
+					Err:      unknownFieldError(k),
+				}
+			}
+			entry[k] = v
+		}
+
+		jsonBytes, err := json.Marshal(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var translateString TranslateString
+		err = json.Unmarshal(jsonBytes, &translateString)
+		if err != nil {
+			position := table.position
+			var typeError *json.UnmarshalTypeError
+			if errors.As(err, &typeError) {
+				for k, keyPosition := range table.keyPositions {
+					if strings.EqualFold(k, typeError.Field) {
+						position = keyPosition
+					}
+				}
+			}
+			return nil, nil, &LoadError{Position: position, Key: table.name, Err: err}
+		}
+
+		translateStrings = append(translateStrings, translateString)
+		positions = append(positions, table.position)
+	}
+
+	return translateStrings, positions, nil
+}
+
+// tomlTable is a TOML table with its dotted name, its values
+// and the positions of the table and its keys
+type tomlTable struct {
+	name         string
+	position     Position
+	values       map[string]any
+	keyPositions map[string]Position
+}
+
+// parseToml parses the subset of TOML that is needed for translation
+// files: tables, strings (basic, literal and multiline), integers,
+// booleans and arrays of those values
+func parseToml(data []byte) ([]tomlTable, error) {
+	p := &tomlParser{data: data, src: string(data), tracker: newPositionTracker(data)}
+	return p.parse()
+}
+
+type tomlParser struct {
+	data    []byte
+	src     string
+	pos     int
+	tracker *positionTracker
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return &LoadError{
+		Position: p.position(),
+		Err:      fmt.Errorf("toml: "+format, args...),
+	}
+}
+
+func (p *tomlParser) position() Position {
+	return p.tracker.at(p.pos)
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *tomlParser) advance() byte {
+	c := p.src[p.pos]
+	p.pos++
+	return c
+}
+
+// skipSpaces skips spaces and tabs, but not new lines
+func (p *tomlParser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips spaces, new lines and comments
+func (p *tomlParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.advance()
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// endLine makes sure that nothing but a comment follows on the line
+func (p *tomlParser) endLine() error {
+	p.skipSpaces()
+	if p.peek() == '#' {
+		p.skipComment()
+	}
+	if p.peek() == '\r' {
+		p.pos++
+	}
+	if !p.eof() && p.peek() != '\n' {
+		return p.errorf("unexpected %q at the end of the line", p.peek())
+	}
+	return nil
+}
+
+func (p *tomlParser) parse() ([]tomlTable, error) {
+	var tables []tomlTable
+	current := -1
+	// seen has the defined table names and if they are arrays of tables
+	seen := map[string]bool{}
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return tables, nil
+		}
+
+		if p.peek() == '[' {
+			position := p.position()
+			p.pos++
+			// An array of tables ([[name]]) can be repeated, it is used
+			// for the keys that are defined with multiple contexts
+			isArray := p.peek() == '['
+			if isArray {
+				p.pos++
+			}
+			p.skipSpaces()
+			name, err := p.parseDottedKey()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpaces()
+			if p.peek() != ']' {
+				return nil, p.errorf("expected ']' to close the table name")
+			}
+			p.pos++
+			if isArray {
+				if p.peek() != ']' {
+					return nil, p.errorf("expected ']]' to close the array of tables name")
+				}
+				p.pos++
+			}
+			if err := p.endLine(); err != nil {
+				return nil, err
+			}
+			if wasArray, exists := seen[name]; exists && (!wasArray || !isArray) {
+				return nil, p.errorf("table '%s' is defined more than once", name)
+			}
+			seen[name] = isArray
+			tables = append(tables, tomlTable{
+				name:         name,
+				position:     position,
+				values:       map[string]any{},
+				keyPositions: map[string]Position{},
+			})
+			current = len(tables) - 1
+			continue
+		}
+
+		keyPosition := p.position()
+		key, err := p.parseDottedKey()
+		if err != nil {
+			return nil, err
+		}
+		if current < 0 {
+			return nil, p.errorf("the key '%s' must be inside a table", key)
+		}
+		p.skipSpaces()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected '=' after the key '%s'", key)
+		}
+		p.pos++
+		p.skipSpaces()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.endLine(); err != nil {
+			return nil, err
+		}
+		if _, exists := tables[current].values[key]; exists {
+			return nil, p.errorf("the key '%s' is defined more than once", key)
+		}
+		tables[current].values[key] = value
+		tables[current].keyPositions[key] = keyPosition
+	}
+}
+
+// parseDottedKey parses bare, quoted and dotted keys,
+// the parts are joined with a dot
+func (p *tomlParser) parseDottedKey() (string, error) {
+	var parts []string
+	for {
+		p.skipSpaces()
+		var part string
+		switch p.peek() {
+		case '"':
+			s, err := p.parseBasicString()
+			if err != nil {
+				return "", err
+			}
+			part = s
+		case '\'':
+			s, err := p.parseLiteralString()
+			if err != nil {
+				return "", err
+			}
+			part = s
+		default:
+			start := p.pos
+			for !p.eof() && isTomlBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return "", p.errorf("expected a key, got %q", p.peek())
+			}
+			part = p.src[start:p.pos]
+		}
+		parts = append(parts, part)
+
+		p.skipSpaces()
+		if p.peek() != '.' {
+			return strings.Join(parts, "."), nil
+		}
+		p.pos++
+	}
+}
+
+func isTomlBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		return p.parseMultilineBasicString()
+	case strings.HasPrefix(p.src[p.pos:], `'''`):
+		return p.parseMultilineLiteralString()
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case strings.HasPrefix(p.src[p.pos:], "true"):
+		p.pos += len("true")
+		return true, nil
+	case strings.HasPrefix(p.src[p.pos:], "false"):
+		p.pos += len("false")
+		return false, nil
+	}
+
+	start := p.pos
+	for !p.eof() && (isTomlBareKeyChar(p.peek()) || p.peek() == '+') {
+		p.pos++
+	}
+	raw := strings.ReplaceAll(p.src[start:p.pos], "_", "")
+	number, err := strconv.ParseInt(raw, 0, 64)
+	if err != nil || raw == "" {
+		return nil, p.errorf("invalid value %q", p.src[start:p.pos])
+	}
+	return number, nil
+}
+
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.pos++ // [
+	values := []any{}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ']' {
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // '
+	start := p.pos
+	for !p.eof() && p.peek() != '\'' {
+		if p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		p.pos++
+	}
+	if p.eof() {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.src[start:p.pos]
+	p.pos++
+	return s, nil
+}
+
+func (p *tomlParser) parseMultilineLiteralString() (string, error) {
+	p.pos += 3
+	p.trimFirstNewLine()
+	end := strings.Index(p.src[p.pos:], "'''")
+	if end < 0 {
+		return "", p.errorf("unterminated multiline string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	for i := 0; i < end+3; i++ {
+		p.advance()
+	}
+	return s, nil
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // "
+	var sb strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.advance()
+		if c == '"' {
+			return sb.String(), nil
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+		if err := p.parseEscape(&sb); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (p *tomlParser) parseMultilineBasicString() (string, error) {
+	p.pos += 3
+	p.trimFirstNewLine()
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multiline string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], `"""`) {
+			p.pos += 3
+			return sb.String(), nil
+		}
+		c := p.advance()
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+		// A line ending backslash trims all the following whitespace
+		if rest := strings.TrimLeft(p.src[p.pos:], " \t\r"); strings.HasPrefix(rest, "\n") {
+			for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+				p.advance()
+			}
+			continue
+		}
+		if err := p.parseEscape(&sb); err != nil {
+			return "", err
+		}
+	}
+}
+
+// trimFirstNewLine trims the new line that immediately
+// follows the opening delimiter of a multiline string
+func (p *tomlParser) trimFirstNewLine() {
+	if strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.pos++
+	}
+	if p.peek() == '\n' {
+		p.advance()
+	}
+}
+
+func (p *tomlParser) parseEscape(sb *strings.Builder) error {
+	if p.eof() {
+		return p.errorf("unterminated escape sequence")
+	}
+	c := p.advance()
+	switch c {
+	case 'b':
+		sb.WriteByte('\b')
+	case 't':
+		sb.WriteByte('\t')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'r':
+		sb.WriteByte('\r')
+	case '"':
+		sb.WriteByte('"')
+	case '\\':
+		sb.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.src) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape")
+		}
+		p.pos += size
+		sb.WriteRune(rune(code))
+	default:
+		return p.errorf("invalid escape sequence '\\%c'", c)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package goeasyi18n
+
+import (
+	"embed"
+	"testing"
+)
+
+func TestLoadFromTomlBytes(t *testing.T) {
+	bytes := []byte("[hello]\nDefault = \"Hello\"\n")
+	strings, err := LoadFromTomlBytes(bytes)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(strings) != 1 || strings[0].Key != "hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+	if strings[0].Default != "Hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+}
+
+func TestLoadFromTomlString(t *testing.T) {
+	strings, err := LoadFromTomlString("[hello]\nDefault = \"Hello\"\n")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(strings) != 1 || strings[0].Key != "hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+	if strings[0].Default != "Hello" {
+		t.Errorf("Unexpected result: %v", strings)
+	}
+}
+
+func TestLoadFromTomlFiles(t *testing.T) {
+	t.Run("load single file", func(t *testing.T) {
+		strings, err := LoadFromTomlFiles("./testfiles/test1.toml")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 1 || strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Default != "Hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load multiple files", func(t *testing.T) {
+		strings, err := LoadFromTomlFiles(
+			"./testfiles/test1.toml",
+			"./testfiles/test2.toml",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[1].Key != "world" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load with glob pattern", func(t *testing.T) {
+		strings, err := LoadFromTomlFiles("./testfiles/test*.toml")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("handle incorrect toml", func(t *testing.T) {
+		_, err := LoadFromTomlFiles("./testfiles/incorrect.toml")
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromTomlFiles("./testfiles/nomatch*.toml")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+//go:embed testfiles/*
+var tomlTestFiles embed.FS
+
+func TestLoadFromTomlFS(t *testing.T) {
+	t.Run("load single file", func(t *testing.T) {
+		strings, err := LoadFromTomlFS(
+			tomlTestFiles,
+			"testfiles/test1.toml",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 1 || strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Default != "Hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load multiple files", func(t *testing.T) {
+		strings, err := LoadFromTomlFS(
+			tomlTestFiles,
+			"testfiles/test1.toml",
+			"testfiles/test2.toml",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+		if strings[1].Key != "world" {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("load with glob pattern", func(t *testing.T) {
+		strings, err := LoadFromTomlFS(
+			tomlTestFiles,
+			"testfiles/test*.toml",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if len(strings) != 2 {
+			t.Errorf("Unexpected result: %v", strings)
+		}
+	})
+
+	t.Run("handle incorrect toml", func(t *testing.T) {
+		_, err := LoadFromTomlFS(
+			tomlTestFiles,
+			"testfiles/incorrect.toml",
+		)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+
+	t.Run("handle no match glob", func(t *testing.T) {
+		_, err := LoadFromTomlFS(
+			tomlTestFiles,
+			"testfiles/nomatch*.toml",
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoadFromTomlSyntax(t *testing.T) {
+	t.Run("load variants, nested tables and every kind of string", func(t *testing.T) {
+		strings, err := LoadFromTomlString(`
+# Comments are ignored
+[hello_emails]
+One = "You have one email" # trailing comment
+Many = 'You have {{.EmailQty}} emails'
+
+[auth.title]
+Default = """
+Sign in\tnow ¡"""
+
+["with spaces"]
+Default = '''raw \n text'''
+`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(strings) != 3 {
+			t.Fatalf("Unexpected result: %v", strings)
+		}
+		if strings[0].Key != "hello_emails" ||
+			strings[0].One != "You have one email" ||
+			strings[0].Many != "You have {{.EmailQty}} emails" {
+			t.Errorf("Unexpected result: %v", strings[0])
+		}
+		if strings[1].Key != "auth.title" || strings[1].Default != "Sign in\tnow ¡" {
+			t.Errorf("Unexpected result: %v", strings[1])
+		}
+		if strings[2].Key != "with spaces" || strings[2].Default != `raw \n text` {
+			t.Errorf("Unexpected result: %v", strings[2])
+		}
+	})
+
+	t.Run("handle incorrect toml", func(t *testing.T) {
+		tests := []string{
+			`Default = "outside of a table"`,
+			"[hello]\nDefault = \"unterminated",
+			"[hello]\nDefault = \"Hello\"\nDefault = \"Again\"",
+			"[hello]\n[hello]",
+			"[hello]\n[[hello]]",
+			"[[hello]\nDefault = \"Hello\"",
+			"[hello]\nDefault = \"Hello\" extra",
+		}
+
+		for _, test := range tests {
+			_, err := LoadFromTomlString(test)
+			if err == nil {
+				t.Errorf("Expected error for %q, got nil", test)
+			}
+		}
+	})
+}
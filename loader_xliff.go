@@ -0,0 +1,293 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// LoadFromXliffBytes loads the translations of the source and
+// target languages from the provided XLIFF 1.2 bytes, it returns
+// a map with the language name as the key.
+//
+// Every trans-unit is a variant of a translation, its resname is
+// the key and its id ends with ".Variant" for the variants other
+// than Default (like "hello_emails.One"):
+//
+//	<file source-language="en" target-language="es" datatype="plaintext" original="messages">
+//	  <body>
+//	    <trans-unit id="hello" resname="hello" maxwidth="20" size-unit="char">
+//	      <source>Hello</source>
+//	      <target state="final">Hola</target>
+//	      <note>Greeting of the home page</note>
+//	    </trans-unit>
+//	  </body>
+//	</file>
+//
+// The note is the Description, the maxwidth is the MaxLength and the
+// state of the target is the Status ("final" is approved, the states
+// that need a review are needs-review and the "fuzzy-match" qualifier
+// is fuzzy). The Context is a context with the "x-context" type, the
+// comma separated Tags are a note from "x-tags" and the Status of the
+// source language is a note from "x-status".
+func LoadFromXliffBytes(
+	xliffBytes []byte,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromBytes(decodeXliff, xliffBytes, defaultLoadOptions)
+}
+
+// LoadFromXliffString loads the translations of the source and
+// target languages from the provided XLIFF 1.2 string.
+func LoadFromXliffString(
+	xliffString string,
+) (map[string]TranslateStrings, error) {
+	return LoadFromXliffBytes([]byte(xliffString))
+}
+
+// LoadFromXliffFiles loads the translations of the source and target
+// languages from one or multiple XLIFF 1.2 files, allowing glob
+// patterns like "path/to/files/*.xlf".
+func LoadFromXliffFiles(
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFiles(decodeXliff, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromXliffFS loads the translations of the source and target
+// languages from one or multiple XLIFF 1.2 files located within a
+// provided filesystem (fs.FS), allowing glob patterns
+// like "path/to/files/*.xlf".
+func LoadFromXliffFS(
+	fileSystem fs.FS,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFS(decodeXliff, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromXliffBytesWithOptions is LoadFromXliffBytes with options,
+// like the policy for the duplicated keys.
+func LoadFromXliffBytesWithOptions(
+	xliffBytes []byte,
+	options LoadOptions,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromBytes(decodeXliff, xliffBytes, options)
+}
+
+// LoadFromXliffFilesWithOptions is LoadFromXliffFiles with options,
+// like the policy for the duplicated keys.
+func LoadFromXliffFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFiles(decodeXliff, filesOrGlobs, options)
+}
+
+// LoadFromXliffFSWithOptions is LoadFromXliffFS with options,
+// like the policy for the duplicated keys.
+func LoadFromXliffFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (map[string]TranslateStrings, error) {
+	return loadMultipleFromFS(decodeXliff, fileSystem, filesOrGlobs, options)
+}
+
+// xliffDocument is the structure of the XLIFF 1.2 files
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID            string              `xml:"id,attr"`
+	Resname       string              `xml:"resname,attr,omitempty"`
+	MaxWidth      int                 `xml:"maxwidth,attr,omitempty"`
+	SizeUnit      string              `xml:"size-unit,attr,omitempty"`
+	Source        string              `xml:"source"`
+	Target        *xliffTarget        `xml:"target"`
+	Notes         []xliffNote         `xml:"note"`
+	ContextGroups []xliffContextGroup `xml:"context-group"`
+}
+
+type xliffTarget struct {
+	State          string `xml:"state,attr,omitempty"`
+	StateQualifier string `xml:"state-qualifier,attr,omitempty"`
+	Text           string `xml:",chardata"`
+}
+
+type xliffNote struct {
+	From string `xml:"from,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type xliffContextGroup struct {
+	Purpose  string         `xml:"purpose,attr,omitempty"`
+	Contexts []xliffContext `xml:"context"`
+}
+
+type xliffContext struct {
+	Type string `xml:"context-type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// xliffContextType is the context type of the Context field
+const xliffContextType = "x-context"
+
+// xliffTagsNote and xliffStatusNote are the "from" of the notes with
+// the comma separated Tags and with the Status of the source language
+const (
+	xliffTagsNote   = "x-tags"
+	xliffStatusNote = "x-status"
+)
+
+func decodeXliff(
+	xliffBytes []byte,
+) (map[string]TranslateStrings, map[string][]Position, error) {
+	var document xliffDocument
+	decoder := xml.NewDecoder(bytes.NewReader(xliffBytes))
+	if err := decoder.Decode(&document); err != nil {
+		var syntaxError *xml.SyntaxError
+		if errors.As(err, &syntaxError) {
+			return nil, nil, &LoadError{Position: Position{Line: syntaxError.Line}, Err: fmt.Errorf("xliff: %s", syntaxError.Msg)}
+		}
+		return nil, nil, &LoadError{Err: fmt.Errorf("xliff: %w", err)}
+	}
+
+	translations := map[string]TranslateStrings{}
+	positions := map[string][]Position{}
+
+	for _, file := range document.Files {
+		if file.SourceLanguage == "" {
+			return nil, nil, &LoadError{Err: fmt.Errorf("xliff: the file '%s' doesn't have a source-language", file.Original)}
+		}
+
+		// The variants of a translation are merged by key and context
+		type xliffEntry struct {
+			source *TranslateString
+			target *TranslateString
+		}
+		entries := map[string]*xliffEntry{}
+		var order []string
+
+		for _, unit := range file.Units {
+			key := unit.Resname
+			if key == "" {
+				key = unit.ID
+			}
+			variant := xliffUnitVariant(unit.ID, key)
+
+			context := ""
+			for _, group := range unit.ContextGroups {
+				for _, c := range group.Contexts {
+					if c.Type == xliffContextType {
+						context = c.Text
+					}
+				}
+			}
+
+			id := translationID(key, context)
+			entry, exists := entries[id]
+			if !exists {
+				entry = &xliffEntry{source: &TranslateString{Key: key, Context: context}}
+				entries[id] = entry
+				order = append(order, id)
+			}
+
+			setXliffMetadata(entry.source, unit)
+			for _, note := range unit.Notes {
+				if note.From == xliffStatusNote {
+					entry.source.Status = TranslationStatus(strings.TrimSpace(note.Text))
+				}
+			}
+			if err := setTranslateStringField(entry.source, variant, unit.Source); err != nil {
+				return nil, nil, &LoadError{Key: key, Err: err}
+			}
+
+			if unit.Target == nil || file.TargetLanguage == "" {
+				continue
+			}
+			if entry.target == nil {
+				entry.target = &TranslateString{Key: key, Context: context}
+			}
+			setXliffMetadata(entry.target, unit)
+			if err := setTranslateStringField(entry.target, variant, unit.Target.Text); err != nil {
+				return nil, nil, &LoadError{Key: key, Err: err}
+			}
+			switch {
+			case unit.Target.StateQualifier == "fuzzy-match":
+				entry.target.Status = StatusFuzzy
+			case unit.Target.State == "final" || unit.Target.State == "signed-off":
+				entry.target.Status = StatusApproved
+			case strings.HasPrefix(unit.Target.State, "needs-review"):
+				entry.target.Status = StatusNeedsReview
+			}
+		}
+
+		for _, id := range order {
+			entry := entries[id]
+			translations[file.SourceLanguage] = append(translations[file.SourceLanguage], *entry.source)
+			positions[file.SourceLanguage] = append(positions[file.SourceLanguage], Position{})
+			if entry.target != nil {
+				translations[file.TargetLanguage] = append(translations[file.TargetLanguage], *entry.target)
+				positions[file.TargetLanguage] = append(positions[file.TargetLanguage], Position{})
+			}
+		}
+	}
+
+	return translations, positions, nil
+}
+
+// xliffUnitVariant returns the variant of a trans-unit, its id is
+// the key followed by ".Variant" for the variants other than Default
+func xliffUnitVariant(id string, key string) string {
+	dot := strings.LastIndex(id, ".")
+	if id == key || dot < 0 || !isVariantName(id[dot+1:]) || !strings.HasSuffix(id[:dot], key) {
+		return "Default"
+	}
+	return id[dot+1:]
+}
+
+// setXliffMetadata sets the metadata of the trans-unit, the notes
+// without a known "from" are the Description
+func setXliffMetadata(ts *TranslateString, unit xliffUnit) {
+	var descriptions []string
+	for _, note := range unit.Notes {
+		switch note.From {
+		case xliffTagsNote:
+			ts.Tags = splitXliffTags(note.Text)
+		case xliffStatusNote:
+		default:
+			descriptions = append(descriptions, note.Text)
+		}
+	}
+	if len(descriptions) > 0 {
+		ts.Description = strings.Join(descriptions, "\n")
+	}
+	if unit.MaxWidth > 0 {
+		ts.MaxLength = unit.MaxWidth
+	}
+}
+
+// splitXliffTags splits the comma separated tags of a note
+func splitXliffTags(text string) []string {
+	var tags []string
+	for _, tag := range strings.Split(text, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
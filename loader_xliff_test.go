@@ -0,0 +1,68 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadFromXliff(t *testing.T) {
+	t.Run("load the source and target languages", func(t *testing.T) {
+		translations, err := LoadFromXliffString(`<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="messages" source-language="en" target-language="es" datatype="plaintext">
+    <body>
+      <trans-unit id="hello" resname="hello" maxwidth="20" size-unit="char">
+        <source>Hello {{.Name}}</source>
+        <target state="final">Hola {{.Name}}</target>
+        <note>Greeting</note>
+      </trans-unit>
+      <trans-unit id="emails.One" resname="emails">
+        <source>One email</source>
+        <target state="needs-review-translation" state-qualifier="fuzzy-match">Un correo</target>
+      </trans-unit>
+      <trans-unit id="emails.Many" resname="emails">
+        <source>Many emails</source>
+      </trans-unit>
+      <trans-unit id="verb/open" resname="open">
+        <source>Open</source>
+        <target state="needs-review-translation">Abrir</target>
+        <context-group purpose="information">
+          <context context-type="x-context">verb</context>
+        </context-group>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]TranslateStrings{
+			"en": {
+				{Key: "hello", Default: "Hello {{.Name}}", Description: "Greeting", MaxLength: 20},
+				{Key: "emails", One: "One email", Many: "Many emails"},
+				{Key: "open", Context: "verb", Default: "Open"},
+			},
+			"es": {
+				{Key: "hello", Default: "Hola {{.Name}}", Description: "Greeting", MaxLength: 20, Status: StatusApproved},
+				{Key: "emails", One: "Un correo", Status: StatusFuzzy},
+				{Key: "open", Context: "verb", Default: "Abrir", Status: StatusNeedsReview},
+			},
+		}
+		if !reflect.DeepEqual(translations, expected) {
+			t.Errorf("Unexpected result: %#v", translations)
+		}
+	})
+
+	t.Run("handle incorrect xliff", func(t *testing.T) {
+		_, err := LoadFromXliffString("<xliff version=\"1.2\">\n<file source-language=\"en\">\n</xliff>")
+		if err == nil || err.Error() != "goeasyi18n: line 3: xliff: element <file> closed by </xliff>" {
+			t.Errorf("Unexpected result: %v", err)
+		}
+
+		_, err = LoadFromXliffString(`<xliff version="1.2"><file original="x"></file></xliff>`)
+		if err == nil {
+			t.Errorf("Expected error, got nil")
+		}
+	})
+}
@@ -2,9 +2,11 @@ package goeasyi18n
 
 import (
 	"encoding/json"
+	"errors"
 	"io/fs"
-	"os"
-	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,27 +16,7 @@ import (
 func LoadFromYamlBytes(
 	yamlBytes []byte,
 ) (TranslateStrings, error) {
-	// This function uses a bridge, it converts YAML to JSON before
-	// parsing it as a TranslateString to avoid inconsistencies
-
-	var parsedYaml any
-	err := yaml.Unmarshal(yamlBytes, &parsedYaml)
-	if err != nil {
-		return nil, err
-	}
-
-	jsonBytes, err := json.Marshal(parsedYaml)
-	if err != nil {
-		return nil, err
-	}
-
-	var translateStrings TranslateStrings
-	err = json.Unmarshal(jsonBytes, &translateStrings)
-	if err != nil {
-		return nil, err
-	}
-
-	return translateStrings, nil
+	return loadFromBytes(decodeYaml, yamlBytes, defaultLoadOptions)
 }
 
 // LoadFromYamlString loads a list of TranslateString
@@ -51,30 +33,7 @@ func LoadFromYamlString(
 func LoadFromYamlFiles(
 	filesOrGlobs ...string,
 ) (TranslateStrings, error) {
-	var allTranslateStrings TranslateStrings
-
-	for _, pattern := range filesOrGlobs {
-		matches, err := filepath.Glob(pattern)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, file := range matches {
-			byteValue, err := os.ReadFile(file)
-			if err != nil {
-				return nil, err
-			}
-
-			translateString, err := LoadFromYamlBytes(byteValue)
-			if err != nil {
-				return nil, err
-			}
-
-			allTranslateStrings = append(allTranslateStrings, translateString...)
-		}
-	}
-
-	return allTranslateStrings, nil
+	return loadFromFiles(decodeYaml, filesOrGlobs, defaultLoadOptions)
 }
 
 // LoadFromYamlFS loads a list of TranslateString from
@@ -85,32 +44,174 @@ func LoadFromYamlFS(
 	fileSystem fs.FS,
 	filesOrGlobs ...string,
 ) (TranslateStrings, error) {
-	var allTranslateStrings TranslateStrings
+	return loadFromFS(decodeYaml, fileSystem, filesOrGlobs, defaultLoadOptions)
+}
+
+// LoadFromYamlBytesWithOptions is LoadFromYamlBytes with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromYamlBytesWithOptions(
+	yamlBytes []byte,
+	options LoadOptions,
+) (TranslateStrings, error) {
+	return loadFromBytes(decodeYaml, yamlBytes, options)
+}
+
+// LoadFromYamlFilesWithOptions is LoadFromYamlFiles with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromYamlFilesWithOptions(
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFiles(decodeYaml, filesOrGlobs, options)
+}
+
+// LoadFromYamlFSWithOptions is LoadFromYamlFS with options,
+// like the strict mode or the policy for the duplicated keys.
+func LoadFromYamlFSWithOptions(
+	fileSystem fs.FS,
+	options LoadOptions,
+	filesOrGlobs ...string,
+) (TranslateStrings, error) {
+	return loadFromFS(decodeYaml, fileSystem, filesOrGlobs, options)
+}
+
+// decodeYaml decodes the translations one by one
+// to know the position where every translation starts
+func decodeYaml(yamlBytes []byte, strict bool) (TranslateStrings, []Position, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(yamlBytes, &document)
+	if err != nil {
+		return nil, nil, yamlLoadError(err)
+	}
 
-	for _, pattern := range filesOrGlobs {
-		matches, err := fs.Glob(fileSystem, pattern)
+	if len(document.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		var translateStrings TranslateStrings
+		err := yamlNodeToJson(root, &translateStrings)
 		if err != nil {
-			return nil, err
+			return nil, nil, &LoadError{Position: yamlNodePosition(root), Err: err}
+		}
+		return translateStrings, nil, nil
+	}
+
+	translateStrings := TranslateStrings{}
+	err = yamlNodeToJson(root, &translateStrings)
+	if err != nil {
+		return nil, nil, yamlItemError(root, err)
+	}
+
+	positions := make([]Position, 0, len(root.Content))
+	for _, item := range root.Content {
+		if strict && item.Kind == yaml.MappingNode {
+			for i := 0; i < len(item.Content); i += 2 {
+				name := item.Content[i]
+				if !isTranslateStringField(name.Value) {
+					return nil, nil, &LoadError{
+						Position: yamlNodePosition(name),
+						Key:      yamlTranslationKey(item),
+						Err:      unknownFieldError(name.Value),
+					}
+				}
+			}
 		}
 
-		if len(matches) == 0 {
+		positions = append(positions, yamlNodePosition(item))
+	}
+
+	return translateStrings, positions, nil
+}
+
+// yamlItemError decodes the translations of the sequence one by
+// one to find the translation (and the field) of the decoding error,
+// the sequence is decoded at once because the bridge is slow
+func yamlItemError(root *yaml.Node, err error) error {
+	for _, item := range root.Content {
+		var translateString TranslateString
+		itemErr := yamlNodeToJson(item, &translateString)
+		if itemErr == nil {
 			continue
 		}
 
-		for _, file := range matches {
-			byteValue, err := readFileFromFS(fileSystem, file)
-			if err != nil {
-				return nil, err
+		position := yamlNodePosition(item)
+		var typeError *json.UnmarshalTypeError
+		if errors.As(itemErr, &typeError) {
+			if field := yamlMappingField(item, typeError.Field); field != nil {
+				position = yamlNodePosition(field)
 			}
+		}
+		return &LoadError{Position: position, Key: yamlTranslationKey(item), Err: itemErr}
+	}
 
-			translateString, err := LoadFromYamlBytes(byteValue)
-			if err != nil {
-				return nil, err
-			}
+	return &LoadError{Position: yamlNodePosition(root), Err: err}
+}
+
+// yamlNodeToJson decodes a YAML node using a bridge, it converts
+// YAML to JSON before parsing it to avoid inconsistencies
+func yamlNodeToJson(node *yaml.Node, target any) error {
+	var parsedYaml any
+	err := node.Decode(&parsedYaml)
+	if err != nil {
+		return err
+	}
+
+	jsonBytes, err := json.Marshal(parsedYaml)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(jsonBytes, target)
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlLoadError gets the line from the
+// message of the YAML syntax errors
+func yamlLoadError(err error) error {
+	matches := yamlErrorLineRegexp.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return &LoadError{Err: err}
+	}
 
-			allTranslateStrings = append(allTranslateStrings, translateString...)
+	line, _ := strconv.Atoi(matches[1])
+	return &LoadError{
+		Position: Position{Line: line},
+		Err:      errors.New("yaml: " + matches[2]),
+	}
+}
+
+func yamlNodePosition(node *yaml.Node) Position {
+	return Position{Line: node.Line, Column: node.Column}
+}
+
+// yamlMappingField returns the node of the name of a field
+// of a mapping (case insensitive), nil if it doesn't exist
+func yamlMappingField(node *yaml.Node, name string) *yaml.Node {
+	if node.Kind != yaml.MappingNode || name == "" {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, name) {
+			return node.Content[i]
 		}
 	}
+	return nil
+}
 
-	return allTranslateStrings, nil
+// yamlTranslationKey gets the key of a translation
+// even if it can't be decoded as a TranslateString
+func yamlTranslationKey(node *yaml.Node) string {
+	field := yamlMappingField(node, "Key")
+	if field == nil {
+		return ""
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i] == field {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
 }
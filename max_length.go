@@ -0,0 +1,115 @@
+package goeasyi18n
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"text/template"
+	"unicode/utf8"
+)
+
+// MaxLengthViolation is a translation that is longer
+// than the MaxLength of its key
+type MaxLengthViolation struct {
+	LanguageName string
+	Key          string
+	Context      string
+	Variant      string // The field of the translation, like "Default" or "Many"
+	Text         string // The translation rendered with the sample data
+	Length       int
+	MaxLength    int
+}
+
+func (v MaxLengthViolation) String() string {
+	return fmt.Sprintf(
+		"goeasyi18n: the %s translation of the key %s in '%s' has %d characters and the max length is %d: %q",
+		v.Variant,
+		describeKey(v.Key, v.Context),
+		v.LanguageName,
+		v.Length,
+		v.MaxLength,
+		v.Text,
+	)
+}
+
+// ValidateMaxLength reports the translations that are longer than
+// their MaxLength (counted in characters) after rendering them with
+// the sample data of their key, the keys without sample data are
+// rendered without data.
+//
+// The MaxLength is usually only set in the source language, so the
+// translations without MaxLength use the one of the same key in
+// the fallback language.
+func (t *I18n) ValidateMaxLength(sampleData map[string]any) []MaxLengthViolation {
+	violations := []MaxLengthViolation{}
+
+	for _, languageName := range t.LanguageNames() {
+		for _, ts := range t.languages[languageName] {
+			maxLength := ts.MaxLength
+			if maxLength == 0 {
+				maxLength = t.fallbackMaxLength(ts.Key, ts.Context)
+			}
+			if maxLength <= 0 {
+				continue
+			}
+
+			reflected := reflect.ValueOf(ts)
+			for _, variant := range variantNames {
+				text := reflected.FieldByName(variant).String()
+				if text == "" {
+					continue
+				}
+
+				rendered := renderSample(text, sampleData[ts.Key])
+				length := utf8.RuneCountInString(rendered)
+				if length <= maxLength {
+					continue
+				}
+
+				violations = append(violations, MaxLengthViolation{
+					LanguageName: languageName,
+					Key:          ts.Key,
+					Context:      ts.Context,
+					Variant:      variant,
+					Text:         rendered,
+					Length:       length,
+					MaxLength:    maxLength,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// fallbackMaxLength returns the MaxLength of a key
+// in the fallback language
+func (t *I18n) fallbackMaxLength(key string, context string) int {
+	for _, ts := range t.languages[t.fallbackLanguageName] {
+		if ts.Key == key && ts.Context == context {
+			return ts.MaxLength
+		}
+	}
+	return 0
+}
+
+// renderSample renders a translation with the sample data, it
+// uses text/template so the length isn't changed by the HTML
+// escaping, and the text is used as it is if it can't be rendered
+func renderSample(text string, data any) string {
+	if data == nil {
+		return text
+	}
+
+	tmpl, err := template.New("sample").Parse(text)
+	if err != nil {
+		return text
+	}
+
+	b := new(bytes.Buffer)
+	if err := tmpl.Execute(b, data); err != nil {
+		return text
+	}
+
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateMaxLength(t *testing.T) {
+	i18n := NewI18n()
+	i18n.AddLanguage("en", TranslateStrings{
+		{Key: "save", Default: "Save", MaxLength: 6},
+		{Key: "greeting", Default: "Hi {{.Name}}", MaxLength: 10},
+		{Key: "emails", One: "One email", Many: "{{.Qty}} emails", MaxLength: 10},
+		{Key: "free", Default: "Without max length"},
+	})
+	i18n.AddLanguage("es", TranslateStrings{
+		{Key: "save", Default: "Guardar"},
+		{Key: "greeting", Default: "Hola {{.Name}}"},
+		{Key: "emails", One: "Un correo", Many: "{{.Qty}} correos", MaxLength: 20},
+		{Key: "free", Default: "Sin longitud máxima"},
+	})
+
+	t.Run("should report the long translations", func(t *testing.T) {
+		violations := i18n.ValidateMaxLength(map[string]any{
+			"greeting": Data{"Name": "Bartholomew"},
+			"emails":   Data{"Qty": 1000},
+		})
+
+		expected := []MaxLengthViolation{
+			{LanguageName: "en", Key: "greeting", Variant: "Default", Text: "Hi Bartholomew", Length: 14, MaxLength: 10},
+			{LanguageName: "en", Key: "emails", Variant: "Many", Text: "1000 emails", Length: 11, MaxLength: 10},
+			{LanguageName: "es", Key: "save", Variant: "Default", Text: "Guardar", Length: 7, MaxLength: 6},
+			{LanguageName: "es", Key: "greeting", Variant: "Default", Text: "Hola Bartholomew", Length: 16, MaxLength: 10},
+		}
+		if !reflect.DeepEqual(violations, expected) {
+			t.Errorf("Unexpected result: %v", violations)
+		}
+	})
+
+	t.Run("should render without sample data", func(t *testing.T) {
+		violations := i18n.ValidateMaxLength(nil)
+		if len(violations) != 4 || violations[0].Text != "Hi {{.Name}}" {
+			t.Errorf("Unexpected result: %v", violations)
+		}
+	})
+
+	t.Run("should describe the violation", func(t *testing.T) {
+		violation := MaxLengthViolation{LanguageName: "es", Key: "save", Variant: "Default", Text: "Guardar", Length: 7, MaxLength: 6}
+		expected := `goeasyi18n: the Default translation of the key 'save' in 'es' has 7 characters and the max length is 6: "Guardar"`
+		if violation.String() != expected {
+			t.Errorf("expected %s; got %s", expected, violation.String())
+		}
+	})
+}
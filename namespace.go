@@ -0,0 +1,66 @@
+package goeasyi18n
+
+import "sort"
+
+// Namespace returns the i18n object of a namespace (like the gettext
+// domains), it is created the first time it is requested.
+//
+// Every namespace has its own translations, so the same key can be
+// used by different modules without collisions, and the consistency
+// checks run per namespace. The config and the pluralization
+// functions (and rules) are shared with the parent i18n object.
+//
+// Example:
+//
+//	billing := i18n.Namespace("billing")
+//	billing.AddLanguage("en", goeasyi18n.TranslateStrings{...})
+//	billing.T("en", "title")
+func (t *I18n) Namespace(namespaceName string) *I18n {
+	if namespace, exists := t.namespaces[namespaceName]; exists {
+		return namespace
+	}
+
+	namespace := &I18n{
+		languages:               make(map[string]TranslateStrings),
+		keyIndexes:              make(map[string]map[string]int),
+		pluralizationFuncs:      t.pluralizationFuncs,
+		pluralRules:             t.pluralRules,
+		fluentResources:         make(map[string]*FluentResource),
+		tenantOverrides:         make(map[string]map[string]TranslateStrings),
+		namespaces:              make(map[string]*I18n),
+		namespaceName:           namespaceName,
+		fallbackLanguageName:    t.fallbackLanguageName,
+		disableConsistencyCheck: t.disableConsistencyCheck,
+		duplicateKeyPolicy:      t.duplicateKeyPolicy,
+		strictLoading:           t.strictLoading,
+		collectLoadErrors:       t.collectLoadErrors,
+		keyAsFallback:           t.keyAsFallback,
+		fuzzyAsMissing:          t.fuzzyAsMissing,
+		onKeyEvent:              t.onKeyEvent,
+	}
+	t.namespaces[namespaceName] = namespace
+
+	return namespace
+}
+
+// HasNamespace checks if a namespace has been created
+func (t *I18n) HasNamespace(namespaceName string) bool {
+	_, exists := t.namespaces[namespaceName]
+	return exists
+}
+
+// NamespaceNames returns the names of the namespaces
+// sorted alphabetically
+func (t *I18n) NamespaceNames() []string {
+	names := make([]string, 0, len(t.namespaces))
+	for name := range t.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RemoveNamespace removes a namespace with all its translations
+func (t *I18n) RemoveNamespace(namespaceName string) {
+	delete(t.namespaces, namespaceName)
+}
@@ -0,0 +1,103 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNamespaces(t *testing.T) {
+	t.Run("the same key should be translated per namespace", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{{Key: "title", Default: "Home"}})
+		i18n.Namespace("billing").AddLanguage("en", TranslateStrings{{Key: "title", Default: "Invoices"}})
+		i18n.Namespace("auth").AddLanguage("en", TranslateStrings{{Key: "title", Default: "Sign in"}})
+
+		tests := []struct {
+			i18n     *I18n
+			expected string
+		}{
+			{i18n, "Home"},
+			{i18n.Namespace("billing"), "Invoices"},
+			{i18n.Namespace("auth"), "Sign in"},
+		}
+
+		for _, test := range tests {
+			got := test.i18n.T("en", "title")
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+
+		if got := i18n.Namespace("billing").NewLangTranslateFunc("es")("title"); got != "Invoices" {
+			t.Errorf("expected the fallback language; got %s", got)
+		}
+	})
+
+	t.Run("the consistency should be checked per namespace", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{{Key: "title", Default: "Home"}})
+		i18n.Namespace("billing").AddLanguage("en", TranslateStrings{
+			{Key: "title", Default: "Invoices"},
+			{Key: "total", Default: "Total"},
+		})
+
+		errors := i18n.AddLanguage("es", TranslateStrings{{Key: "title", Default: "Inicio"}})
+		if len(errors) != 0 {
+			t.Errorf("expected no errors; got %v", errors)
+		}
+
+		errors = i18n.Namespace("billing").AddLanguage("es", TranslateStrings{{Key: "title", Default: "Facturas"}})
+		if len(errors) != 1 || !strings.HasPrefix(errors[0], "goeasyi18n: namespace 'billing': the language 'en' has the key 'total'") {
+			t.Errorf("Unexpected result: %v", errors)
+		}
+	})
+
+	t.Run("the pluralization functions should be shared", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{})
+		i18n.SetPluralizationFunc("en", func(count int) string {
+			if count == 0 {
+				return "Zero"
+			}
+			return DefaultPluralizationFunc(count)
+		})
+
+		billing := i18n.Namespace("billing")
+		billing.AddLanguage("en", TranslateStrings{{Key: "invoices", Zero: "No invoices", Many: "Many invoices"}})
+		billing.RemoveLanguage("en")
+		billing.AddLanguage("en", TranslateStrings{{Key: "invoices", Zero: "No invoices", Many: "Many invoices"}})
+
+		if got := billing.T("en", "invoices", Options{Count: createPtr(0)}); got != "No invoices" {
+			t.Errorf("expected No invoices; got %s", got)
+		}
+	})
+
+	t.Run("a language removed from the root keeps pluralizing in the namespace", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{})
+
+		billing := i18n.Namespace("billing")
+		billing.AddLanguage("en", TranslateStrings{{Key: "invoices", One: "One invoice", Many: "Many invoices"}})
+		i18n.RemoveLanguage("en")
+
+		if got := billing.T("en", "invoices", Options{Count: createPtr(2)}); got != "Many invoices" {
+			t.Errorf("expected Many invoices; got %s", got)
+		}
+	})
+
+	t.Run("the namespaces can be listed and removed", func(t *testing.T) {
+		i18n := NewI18n()
+		i18n.Namespace("billing")
+		i18n.Namespace("auth")
+
+		if !reflect.DeepEqual(i18n.NamespaceNames(), []string{"auth", "billing"}) {
+			t.Errorf("Unexpected result: %v", i18n.NamespaceNames())
+		}
+
+		i18n.RemoveNamespace("auth")
+		if i18n.HasNamespace("auth") || !i18n.HasNamespace("billing") {
+			t.Errorf("Unexpected result: %v", i18n.NamespaceNames())
+		}
+	})
+}
@@ -0,0 +1,326 @@
+package goeasyi18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralRule is a pluralization rule as data instead of Go code, so
+// it can be stored in the compiled catalogs. The rule is a gettext
+// Plural-Forms expression of the count "n" that returns the index
+// of the plural form to use:
+//
+//	rule, err := goeasyi18n.ParsePluralRule(
+//		"n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2",
+//		"One", "Few", "Many",
+//	)
+//	i18n.SetPluralRule("ru", rule)
+type PluralRule struct {
+	expression string
+	forms      []string
+	root       *pluralNode
+}
+
+// pluralNode is a node of a parsed plural expression, the fields
+// are exported so the parsed rules can be gob encoded
+type pluralNode struct {
+	Op    string // "n", "number" or the operator
+	Value int
+	Args  []*pluralNode
+}
+
+// ParsePluralRule parses a gettext plural expression (like "n != 1")
+// or a whole Plural-Forms header value (like "nplurals=2; plural=(n
+// != 1);"), the forms are the plural forms of the indexes returned
+// by the expression (like "One" and "Many")
+func ParsePluralRule(expression string, forms ...string) (*PluralRule, error) {
+	if len(forms) == 0 {
+		return nil, fmt.Errorf("goeasyi18n: the plural rule '%s' has no forms", expression)
+	}
+	for _, form := range forms {
+		if !isPluralFormName(form) {
+			return nil, fmt.Errorf("goeasyi18n: unknown plural form '%s'", form)
+		}
+	}
+
+	source := expression
+	if _, plural, found := strings.Cut(expression, "plural="); found {
+		source, _, _ = strings.Cut(plural, ";")
+	}
+
+	p := &pluralParser{src: source}
+	root, err := p.parseExpression()
+	if err == nil {
+		if p.skipSpaces(); p.pos < len(p.src) {
+			err = fmt.Errorf("unexpected '%s' at %d", p.src[p.pos:], p.pos)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("goeasyi18n: invalid plural rule '%s': %w", expression, err)
+	}
+
+	return &PluralRule{
+		expression: strings.TrimSpace(source),
+		forms:      append([]string{}, forms...),
+		root:       root,
+	}, nil
+}
+
+// Form returns the plural form of the count, the last form
+// is used if the expression returns an index out of the forms
+func (r *PluralRule) Form(count int) string {
+	index := r.root.eval(count)
+	if index < 0 || index >= len(r.forms) {
+		return r.forms[len(r.forms)-1]
+	}
+	return r.forms[index]
+}
+
+// String returns the expression of the rule
+func (r *PluralRule) String() string {
+	return r.expression
+}
+
+// SetPluralRule sets a plural rule as the pluralization function of a
+// language, unlike SetPluralizationFunc the rule is also added to the
+// compiled catalogs exported with ExportCompiledCatalog
+func (t *I18n) SetPluralRule(languageName string, rule *PluralRule) {
+	t.SetPluralizationFunc(languageName, rule.Form)
+	t.pluralRules[languageName] = rule
+}
+
+// isPluralFormName checks if the name is one of the plural forms
+// returned by the pluralization functions
+func isPluralFormName(name string) bool {
+	for _, form := range []string{"Zero", "One", "Two", "Few", "Many"} {
+		if form == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (node *pluralNode) eval(n int) int {
+	boolean := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	switch node.Op {
+	case "n":
+		return n
+	case "number":
+		return node.Value
+	case "!":
+		return boolean(node.Args[0].eval(n) == 0)
+	case "?":
+		if node.Args[0].eval(n) != 0 {
+			return node.Args[1].eval(n)
+		}
+		return node.Args[2].eval(n)
+	case "||":
+		return boolean(node.Args[0].eval(n) != 0 || node.Args[1].eval(n) != 0)
+	case "&&":
+		return boolean(node.Args[0].eval(n) != 0 && node.Args[1].eval(n) != 0)
+	}
+
+	a, b := node.Args[0].eval(n), node.Args[1].eval(n)
+	switch node.Op {
+	case "==":
+		return boolean(a == b)
+	case "!=":
+		return boolean(a != b)
+	case "<":
+		return boolean(a < b)
+	case "<=":
+		return boolean(a <= b)
+	case ">":
+		return boolean(a > b)
+	case ">=":
+		return boolean(a >= b)
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/", "%":
+		if b == 0 {
+			return 0
+		}
+		if node.Op == "/" {
+			return a / b
+		}
+		return a % b
+	}
+	return 0
+}
+
+// isValid checks that the node can be evaluated, the
+// parsed rules of the compiled catalogs are not trusted
+func (node *pluralNode) isValid() bool {
+	if node == nil {
+		return false
+	}
+
+	arity := 2
+	switch node.Op {
+	case "n", "number":
+		arity = 0
+	case "!":
+		arity = 1
+	case "?":
+		arity = 3
+	default:
+		if !isPluralBinaryOperator(node.Op) {
+			return false
+		}
+	}
+	if len(node.Args) != arity {
+		return false
+	}
+	for _, arg := range node.Args {
+		if !arg.isValid() {
+			return false
+		}
+	}
+	return true
+}
+
+func isPluralBinaryOperator(op string) bool {
+	for _, operators := range pluralBinaryOperators {
+		for _, operator := range operators {
+			if operator == op {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pluralBinaryOperators are the binary operators of the plural
+// expressions by precedence, from the lowest to the highest
+var pluralBinaryOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type pluralParser struct {
+	src string
+	pos int
+}
+
+func (p *pluralParser) skipSpaces() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips the token if it is next
+func (p *pluralParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// parseExpression parses a conditional expression (the
+// lowest precedence), the conditions are right associative
+func (p *pluralParser) parseExpression() (*pluralNode, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil || !p.consume("?") {
+		return condition, err
+	}
+
+	then, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(":") {
+		return nil, fmt.Errorf("expected ':' at %d", p.pos)
+	}
+	otherwise, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &pluralNode{Op: "?", Args: []*pluralNode{condition, then, otherwise}}, nil
+}
+
+func (p *pluralParser) parseBinary(level int) (*pluralNode, error) {
+	if level == len(pluralBinaryOperators) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator := ""
+		for _, candidate := range pluralBinaryOperators[level] {
+			if p.consume(candidate) {
+				operator = candidate
+				break
+			}
+		}
+		if operator == "" {
+			return left, nil
+		}
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &pluralNode{Op: operator, Args: []*pluralNode{left, right}}
+	}
+}
+
+func (p *pluralParser) parseUnary() (*pluralNode, error) {
+	if p.consume("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &pluralNode{Op: "!", Args: []*pluralNode{operand}}, nil
+	}
+
+	if p.consume("(") {
+		node, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected ')' at %d", p.pos)
+		}
+		return node, nil
+	}
+
+	if p.consume("n") {
+		return &pluralNode{Op: "n"}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos == len(p.src) {
+			return nil, fmt.Errorf("unexpected end")
+		}
+		return nil, fmt.Errorf("unexpected '%c' at %d", p.src[p.pos], p.pos)
+	}
+	value, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+	return &pluralNode{Op: "number", Value: value}, nil
+}
@@ -0,0 +1,100 @@
+package goeasyi18n
+
+import "testing"
+
+func TestPluralRule(t *testing.T) {
+	russian := "n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2"
+
+	t.Run("should pick the plural forms", func(t *testing.T) {
+		rule, err := ParsePluralRule(russian, "One", "Few", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		tests := map[int]string{
+			0: "Many", 1: "One", 2: "Few", 4: "Few", 5: "Many",
+			11: "Many", 12: "Many", 21: "One", 22: "Few", 111: "Many",
+		}
+		for count, expected := range tests {
+			if got := rule.Form(count); got != expected {
+				t.Errorf("%d: expected %s; got %s", count, expected, got)
+			}
+		}
+	})
+
+	t.Run("should parse a Plural-Forms header", func(t *testing.T) {
+		rule, err := ParsePluralRule("nplurals=2; plural=(n != 1);", "One", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if rule.String() != "(n != 1)" {
+			t.Errorf("expected %s; got %s", "(n != 1)", rule.String())
+		}
+		if rule.Form(1) != "One" || rule.Form(0) != "Many" || rule.Form(7) != "Many" {
+			t.Errorf("Unexpected result: %s %s %s", rule.Form(1), rule.Form(0), rule.Form(7))
+		}
+	})
+
+	t.Run("should use the last form out of the forms", func(t *testing.T) {
+		rule, err := ParsePluralRule("n", "Zero", "One", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := rule.Form(10); got != "Many" {
+			t.Errorf("expected %s; got %s", "Many", got)
+		}
+		if got := rule.Form(-1); got != "Many" {
+			t.Errorf("expected %s; got %s", "Many", got)
+		}
+	})
+
+	t.Run("should not divide by zero", func(t *testing.T) {
+		rule, err := ParsePluralRule("n / 0 + n % 0", "One", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := rule.Form(3); got != "One" {
+			t.Errorf("expected %s; got %s", "One", got)
+		}
+	})
+
+	t.Run("should fail with invalid rules", func(t *testing.T) {
+		tests := map[string][]string{
+			"":           {"One"},
+			"n ==":       {"One"},
+			"(n == 1":    {"One"},
+			"n ? 0":      {"One"},
+			"n == 1 x":   {"One"},
+			"n != 1":     {},
+			"n != 1 ? 1": {"One", "Other"},
+		}
+		for expression, forms := range tests {
+			if _, err := ParsePluralRule(expression, forms...); err == nil {
+				t.Errorf("Expected error, got nil: %s", expression)
+			}
+		}
+	})
+
+	t.Run("should be the pluralization function of the language", func(t *testing.T) {
+		rule, err := ParsePluralRule(russian, "One", "Few", "Many")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		i18n := NewI18n()
+		i18n.AddLanguage("ru", TranslateStrings{
+			{Key: "files", One: "{{.Count}} файл", Few: "{{.Count}} файла", Many: "{{.Count}} файлов"},
+		})
+		i18n.SetPluralRule("ru", rule)
+
+		for count, expected := range map[int]string{1: "1 файл", 3: "3 файла", 5: "5 файлов"} {
+			got := i18n.T("ru", "files", Options{Count: &count, Data: map[string]int{"Count": count}})
+			if got != expected {
+				t.Errorf("expected %s; got %s", expected, got)
+			}
+		}
+
+		i18n.SetPluralizationFunc("ru", DefaultPluralizationFunc)
+		if _, exists := i18n.pluralRules["ru"]; exists {
+			t.Errorf("Unexpected result: the plural rule was kept")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// PseudoStyle is the kind of pseudo-localization of the texts
+type PseudoStyle int
+
+const (
+	// PseudoAccented accents the letters, pads the texts to about 130%
+	// of their length and wraps them in brackets, like the en-XA locale
+	PseudoAccented PseudoStyle = iota
+	// PseudoRightToLeft shows the words right-to-left
+	// with bidi control characters, like the ar-XB locale
+	PseudoRightToLeft
+)
+
+// pseudoProtectedRegexp matches the parts of the texts that are not
+// pseudo-localized: the template actions, HTML tags and HTML entities
+var pseudoProtectedRegexp = regexp.MustCompile(`(?s){{.*?}}|<[^<>]*>|&#?[0-9A-Za-z]+;`)
+
+// pseudoAccents are the accented versions of the ASCII letters
+var pseudoAccents = map[rune]rune{
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Đ', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ',
+	'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ĺ', 'M': 'Ṁ', 'N': 'Ñ',
+	'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ŧ', 'U': 'Û',
+	'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ',
+	'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ',
+	'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û',
+	'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+}
+
+// pseudoPadding is repeated to make the accented texts longer
+const pseudoPadding = "one two three four five six seven eight nine ten "
+
+// The bidi control characters of the right-to-left texts
+const (
+	rightToLeftMark     = "\u200f"
+	rightToLeftOverride = "\u202e"
+	popDirectionalMark  = "\u202c"
+)
+
+// PseudoLocalize returns the pseudo-localized version of a text, the
+// template actions (like {{.Name}}), HTML tags and HTML entities are
+// kept as they are so the text can still be executed with data
+func PseudoLocalize(text string, style PseudoStyle) string {
+	if text == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	length := 0
+	last := 0
+	for _, match := range pseudoProtectedRegexp.FindAllStringIndex(text, -1) {
+		length += pseudoLocalizeText(&sb, text[last:match[0]], style)
+		sb.WriteString(text[match[0]:match[1]])
+		last = match[1]
+	}
+	length += pseudoLocalizeText(&sb, text[last:], style)
+
+	if style == PseudoRightToLeft {
+		return rightToLeftMark + sb.String() + rightToLeftMark
+	}
+
+	// About 30% longer, like the texts of many languages compared with English
+	padding := ""
+	if extra := (length*3 + 9) / 10; extra > 0 {
+		for utf8.RuneCountInString(padding) < extra {
+			padding += pseudoPadding
+		}
+		padding = " " + strings.TrimSpace(string([]rune(padding)[:extra]))
+	}
+	return "[" + sb.String() + padding + "]"
+}
+
+// pseudoLocalizeText writes a part of a text without template actions
+// and returns its length in characters without the spaces
+func pseudoLocalizeText(sb *strings.Builder, text string, style PseudoStyle) int {
+	length := 0
+	inWord := false
+	for _, r := range text {
+		isSpace := strings.ContainsRune(" \t\r\n", r)
+		if !isSpace {
+			length++
+		}
+
+		if style == PseudoRightToLeft {
+			if isSpace && inWord {
+				sb.WriteString(popDirectionalMark)
+			} else if !isSpace && !inWord {
+				sb.WriteString(rightToLeftOverride)
+			}
+			inWord = !isSpace
+			sb.WriteRune(r)
+			continue
+		}
+
+		if accented, exists := pseudoAccents[r]; exists {
+			r = accented
+		}
+		sb.WriteRune(r)
+	}
+
+	if inWord {
+		sb.WriteString(popDirectionalMark)
+	}
+	return length
+}
+
+// PseudoLocalizeTranslateStrings returns a copy of the translations
+// with all their variants pseudo-localized
+func PseudoLocalizeTranslateStrings(
+	translateStrings TranslateStrings,
+	style PseudoStyle,
+) TranslateStrings {
+	pseudo := make(TranslateStrings, len(translateStrings))
+	for i, ts := range translateStrings {
+		reflected := reflect.ValueOf(&ts).Elem()
+		for _, variant := range variantNames {
+			field := reflected.FieldByName(variant)
+			field.SetString(PseudoLocalize(field.String(), style))
+		}
+		pseudo[i] = ts
+	}
+	return pseudo
+}
+
+// AddPseudoLanguage adds a pseudo-language (like "en-XA" or "ar-XB")
+// with the translations of the fallback language pseudo-localized, to
+// find the hardcoded texts and the layouts that break with longer or
+// right-to-left texts. It uses the pluralization function of the
+// fallback language and returns the errors of AddLanguage
+func (t *I18n) AddPseudoLanguage(languageName string, style PseudoStyle) []string {
+	translateStrings := PseudoLocalizeTranslateStrings(t.languages[t.fallbackLanguageName], style)
+	errors := t.AddLanguage(languageName, translateStrings)
+
+	if fn, exists := t.pluralizationFuncs[t.fallbackLanguageName]; exists {
+		t.SetPluralizationFunc(languageName, fn)
+	}
+	return errors
+}
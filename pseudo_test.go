@@ -0,0 +1,78 @@
+package goeasyi18n
+
+import "testing"
+
+func TestPseudoLocalize(t *testing.T) {
+	t.Run("should accent, pad and bracket the texts", func(t *testing.T) {
+		tests := map[string]string{
+			"":                        "",
+			"Hello":                   "[Ĥéļļö on]",
+			"Hello world":             "[Ĥéļļö ŵöŕļð one]",
+			"Save all the changes":    "[Šåṽé åļļ ţĥé çĥåñĝéš one tw]",
+			"1 2":                     "[1 2 o]",
+			"{{.Count}}":              "[{{.Count}}]",
+			"Hi {{.Name}}":            "[Ĥî {{.Name}} o]",
+			"<b>Bold</b> &amp; plain": "[<b>Ɓöļð</b> &amp; þļåîñ one]",
+		}
+
+		for text, expected := range tests {
+			if got := PseudoLocalize(text, PseudoAccented); got != expected {
+				t.Errorf("expected %s; got %s", expected, got)
+			}
+		}
+	})
+
+	t.Run("should show the words right-to-left", func(t *testing.T) {
+		got := PseudoLocalize("Hi {{.Name}} friend", PseudoRightToLeft)
+
+		expected := "\u200f\u202eHi\u202c {{.Name}} \u202efriend\u202c\u200f"
+		if got != expected {
+			t.Errorf("expected %q; got %q", expected, got)
+		}
+	})
+}
+
+func TestAddPseudoLanguage(t *testing.T) {
+	newI18n := func() *I18n {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "hello", Default: "Hello {{.Name}}", Description: "Greeting"},
+			{Key: "emails", One: "One email", Many: "{{.Count}} emails"},
+		})
+		i18n.SetPluralizationFunc("en", func(count int) string {
+			if count == 1 {
+				return "One"
+			}
+			return "Many"
+		})
+		return i18n
+	}
+
+	t.Run("should translate with the pseudo-language", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.AddPseudoLanguage("en-XA", PseudoAccented)
+
+		got := i18n.T("en-XA", "hello", Options{Data: map[string]string{"Name": "John"}})
+		if got != "[Ĥéļļö John on]" {
+			t.Errorf("Unexpected result: %s", got)
+		}
+
+		count := 1
+		got = i18n.T("en-XA", "emails", Options{Count: &count})
+		if got != "[Öñé éɱåîļ one]" {
+			t.Errorf("Unexpected result: %s", got)
+		}
+	})
+
+	t.Run("should keep the fallback language and the metadata", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.AddPseudoLanguage("ar-XB", PseudoRightToLeft)
+
+		if got := i18n.T("en", "hello", Options{Data: map[string]string{"Name": "John"}}); got != "Hello John" {
+			t.Errorf("Unexpected result: %s", got)
+		}
+		if got := i18n.Translations("ar-XB")[0].Description; got != "Greeting" {
+			t.Errorf("Unexpected result: %s", got)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package goeasyi18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+)
+
+// HashSourceText returns a short hash of the texts of a translation,
+// it is stored in the SourceHash of the translations of the other
+// languages to know which version of the source text was translated
+func HashSourceText(translateString TranslateString) string {
+	var sb strings.Builder
+	reflected := reflect.ValueOf(translateString)
+	for _, variant := range variantNames {
+		text := reflected.FieldByName(variant).String()
+		if text == "" {
+			continue
+		}
+		sb.WriteString(variant)
+		sb.WriteString("=")
+		sb.WriteString(text)
+		sb.WriteString("\n")
+	}
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// StaleTranslation is a translation whose source text in
+// the fallback language has changed since it was translated
+type StaleTranslation struct {
+	LanguageName string
+	Key          string
+	Context      string
+	RecordedHash string
+	CurrentHash  string
+}
+
+// StaleTranslations returns the translations of all the languages
+// whose SourceHash doesn't match the current source text of the
+// fallback language, the translations without SourceHash and
+// the keys that don't exist in the fallback language are ignored
+func (t *I18n) StaleTranslations() []StaleTranslation {
+	stale := []StaleTranslation{}
+
+	for _, languageName := range t.LanguageNames() {
+		if languageName == t.fallbackLanguageName {
+			continue
+		}
+
+		for _, ts := range t.languages[languageName] {
+			if ts.SourceHash == "" {
+				continue
+			}
+			currentHash, exists := t.currentSourceHash(ts.Key, ts.Context)
+			if !exists || currentHash == ts.SourceHash {
+				continue
+			}
+			stale = append(stale, StaleTranslation{
+				LanguageName: languageName,
+				Key:          ts.Key,
+				Context:      ts.Context,
+				RecordedHash: ts.SourceHash,
+				CurrentHash:  currentHash,
+			})
+		}
+	}
+
+	return stale
+}
+
+// MarkUpdated records the current source text of the fallback
+// language as translated for a key of a language, the context can
+// be passed for a translation with context. It returns false if
+// the translation or the source text doesn't exist
+func (t *I18n) MarkUpdated(
+	languageName string,
+	translateKey string,
+	translateContext ...string,
+) bool {
+	var context string
+	if len(translateContext) > 0 {
+		context = translateContext[0]
+	}
+
+	currentHash, exists := t.currentSourceHash(translateKey, context)
+	if !exists {
+		return false
+	}
+
+	for _, ts := range t.languages[languageName] {
+		if ts.Key == translateKey && ts.Context == context {
+			ts.SourceHash = currentHash
+			t.SetTranslation(languageName, ts)
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarkLanguageUpdated records the current source text of the fallback
+// language as translated for all the translations of a language
+func (t *I18n) MarkLanguageUpdated(languageName string) {
+	for _, ts := range t.languages[languageName] {
+		t.MarkUpdated(languageName, ts.Key, ts.Context)
+	}
+}
+
+// currentSourceHash returns the hash of the
+// source text of a key in the fallback language
+func (t *I18n) currentSourceHash(key string, context string) (string, bool) {
+	for _, ts := range t.languages[t.fallbackLanguageName] {
+		if ts.Key == key && ts.Context == context {
+			return HashSourceText(ts), true
+		}
+	}
+	return "", false
+}
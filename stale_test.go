@@ -0,0 +1,86 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHashSourceText(t *testing.T) {
+	t.Run("the hash should depend only on the texts", func(t *testing.T) {
+		hash := HashSourceText(TranslateString{Key: "hello", Default: "Hello"})
+
+		if len(hash) != 16 {
+			t.Errorf("Unexpected result: %s", hash)
+		}
+		if HashSourceText(TranslateString{Key: "other", Default: "Hello", Description: "Greeting"}) != hash {
+			t.Errorf("expected the same hash for the same texts")
+		}
+		if HashSourceText(TranslateString{Key: "hello", Default: "Hello!"}) == hash {
+			t.Errorf("expected a different hash for a different text")
+		}
+		if HashSourceText(TranslateString{Key: "hello", One: "Hello"}) == hash {
+			t.Errorf("expected a different hash for a different variant")
+		}
+	})
+}
+
+func TestStaleTranslations(t *testing.T) {
+	oldHello := HashSourceText(TranslateString{Default: "Hello"})
+
+	newI18n := func() *I18n {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "hello", Default: "Hello, welcome"},
+			{Key: "bye", Default: "Bye"},
+			{Key: "thanks", Default: "Thanks"},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "hello", Default: "Hola", SourceHash: oldHello},
+			{Key: "bye", Default: "Adios", SourceHash: HashSourceText(TranslateString{Default: "Bye"})},
+			{Key: "thanks", Default: "Gracias"},
+		})
+		return i18n
+	}
+
+	t.Run("should list the outdated translations", func(t *testing.T) {
+		i18n := newI18n()
+
+		expected := []StaleTranslation{{
+			LanguageName: "es",
+			Key:          "hello",
+			RecordedHash: oldHello,
+			CurrentHash:  HashSourceText(TranslateString{Default: "Hello, welcome"}),
+		}}
+		if got := i18n.StaleTranslations(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+
+	t.Run("should mark the translations as updated", func(t *testing.T) {
+		i18n := newI18n()
+
+		if !i18n.MarkUpdated("es", "hello") {
+			t.Errorf("expected hello to be marked")
+		}
+		if i18n.MarkUpdated("es", "xxx") || i18n.MarkUpdated("xx", "hello") {
+			t.Errorf("expected nothing to be marked")
+		}
+		if got := i18n.StaleTranslations(); len(got) != 0 {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+
+	t.Run("should mark a whole language as updated", func(t *testing.T) {
+		i18n := newI18n()
+		i18n.MarkLanguageUpdated("es")
+
+		for _, ts := range i18n.languages["es"] {
+			if ts.SourceHash == "" {
+				t.Errorf("expected a source hash for %s", ts.Key)
+			}
+		}
+		if got := i18n.StaleTranslations(); len(got) != 0 {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package goeasyi18n
+
+import "sort"
+
+// TranslationsByStatus returns the translations of a language that
+// have the status, an empty status returns the translations whose
+// status is not tracked
+func (t *I18n) TranslationsByStatus(
+	languageName string,
+	status TranslationStatus,
+) TranslateStrings {
+	found := TranslateStrings{}
+	for _, ts := range t.languages[languageName] {
+		if ts.Status == status {
+			found = append(found, ts)
+		}
+	}
+	return found
+}
+
+// KeysByStatus returns the keys of a language that have
+// the status, sorted alphabetically and without repetitions
+// (a key can be repeated with different contexts)
+func (t *I18n) KeysByStatus(
+	languageName string,
+	status TranslationStatus,
+) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, ts := range t.TranslationsByStatus(languageName, status) {
+		if !seen[ts.Key] {
+			seen[ts.Key] = true
+			keys = append(keys, ts.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
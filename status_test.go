@@ -0,0 +1,84 @@
+package goeasyi18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	newI18n := func(config ...Config) *I18n {
+		i18n := NewI18n(config...)
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "hello", Default: "Hello"},
+			{Key: "bye", Default: "Bye"},
+			{Key: "thanks", Default: "Thanks"},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "hello", Default: "Hola", Status: StatusApproved},
+			{Key: "bye", Default: "Adios", Status: StatusFuzzy},
+			{Key: "thanks", Default: "Gracias", Status: StatusFuzzy},
+			{Key: "thanks", Context: "formal", Default: "Muchas gracias", Status: StatusFuzzy},
+		})
+		return i18n
+	}
+
+	t.Run("should list the keys by status", func(t *testing.T) {
+		i18n := newI18n()
+
+		tests := []struct {
+			lang     string
+			status   TranslationStatus
+			expected []string
+		}{
+			{"es", StatusFuzzy, []string{"bye", "thanks"}},
+			{"es", StatusApproved, []string{"hello"}},
+			{"es", StatusNeedsReview, []string{}},
+			{"en", "", []string{"bye", "hello", "thanks"}},
+			{"xx", StatusFuzzy, []string{}},
+		}
+
+		for _, test := range tests {
+			got := i18n.KeysByStatus(test.lang, test.status)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v; got %v", test.expected, got)
+			}
+		}
+
+		if got := i18n.TranslationsByStatus("es", StatusFuzzy); len(got) != 3 || got[2].Context != "formal" {
+			t.Errorf("Unexpected result: %v", got)
+		}
+	})
+
+	t.Run("fuzzy translations should be used by default", func(t *testing.T) {
+		i18n := newI18n()
+		if got := i18n.T("es", "bye"); got != "Adios" {
+			t.Errorf("expected Adios; got %s", got)
+		}
+	})
+
+	t.Run("fuzzy translations should be missing with FuzzyAsMissing", func(t *testing.T) {
+		i18n := newI18n(Config{FuzzyAsMissing: true})
+		if got := i18n.T("es", "bye"); got != "Bye" {
+			t.Errorf("expected Bye; got %s", got)
+		}
+		if got := i18n.T("es", "hello"); got != "Hola" {
+			t.Errorf("expected Hola; got %s", got)
+		}
+	})
+
+	t.Run("the status should be loaded and exported", func(t *testing.T) {
+		loaded, err := LoadFromPropertiesString("bye = Adios\nbye.Status = fuzzy\n")
+		if err != nil || len(loaded) != 1 || loaded[0].Status != StatusFuzzy {
+			t.Fatalf("Unexpected result: %v %v", loaded, err)
+		}
+
+		jsonBytes, err := MarshalJson(loaded)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "[\n  {\n    \"Key\": \"bye\",\n    \"Default\": \"Adios\",\n    \"Status\": \"fuzzy\"\n  }\n]\n"
+		if string(jsonBytes) != expected {
+			t.Errorf("expected %s; got %s", expected, string(jsonBytes))
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package goeasyi18n
+
+import "context"
+
+// AddTenantOverrides sets the translations that override the base
+// catalog of a language for a tenant, only the overridden keys are
+// needed because the other keys are resolved from the base catalog.
+//
+// Calling it again for the same tenant and language
+// replaces the previous overrides.
+func (t *I18n) AddTenantOverrides(
+	tenantName string,
+	languageName string,
+	translateStrings TranslateStrings,
+) {
+	if t.tenantOverrides[tenantName] == nil {
+		t.tenantOverrides[tenantName] = make(map[string]TranslateStrings)
+	}
+	t.tenantOverrides[tenantName][languageName] = translateStrings
+}
+
+// RemoveTenantOverrides removes all the overrides of a tenant
+func (t *I18n) RemoveTenantOverrides(tenantName string) {
+	delete(t.tenantOverrides, tenantName)
+}
+
+// HasTenantOverrides checks if a tenant has overrides
+// for at least one language
+func (t *I18n) HasTenantOverrides(tenantName string) bool {
+	return len(t.tenantOverrides[tenantName]) > 0
+}
+
+// tenantTranslateString finds the override of a key
+// for a tenant in a specific language
+func (t *I18n) tenantTranslateString(
+	tenantName string,
+	languageName string,
+	translateKey string,
+	translateContext string,
+) (TranslateString, bool) {
+	if tenantName == "" {
+		return TranslateString{}, false
+	}
+	for _, ts := range t.tenantOverrides[tenantName][languageName] {
+		if ts.Key == translateKey && ts.Context == translateContext && t.isUsable(ts) {
+			return ts, true
+		}
+	}
+	return TranslateString{}, false
+}
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of the context with the
+// tenant to be used by TranslateContext
+func WithTenant(ctx context.Context, tenantName string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantName)
+}
+
+// TenantFromContext returns the tenant set with WithTenant,
+// or an empty string if there is no tenant
+func TenantFromContext(ctx context.Context) string {
+	tenantName, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantName
+}
+
+// TranslateContext works like Translate but uses the tenant of the
+// context (see WithTenant), the Tenant of the options takes precedence
+func (t *I18n) TranslateContext(
+	ctx context.Context,
+	languageName string,
+	translateKey string,
+	options ...Options,
+) string {
+	var pickedOptions Options
+	if len(options) > 0 {
+		pickedOptions = options[0]
+	}
+	if pickedOptions.Tenant == "" {
+		pickedOptions.Tenant = TenantFromContext(ctx)
+	}
+	return t.Translate(languageName, translateKey, pickedOptions)
+}
+
+// TenantI18n is a view of the i18n object for a tenant, the keys are
+// resolved first from the tenant overrides and then from the base
+// catalog, without copying the catalog.
+type TenantI18n struct {
+	i18n       *I18n
+	tenantName string
+}
+
+// Tenant creates a view of the i18n object for a tenant
+func (t *I18n) Tenant(tenantName string) *TenantI18n {
+	return &TenantI18n{
+		i18n:       t,
+		tenantName: tenantName,
+	}
+}
+
+// Translate translates a string like I18n.Translate using the tenant
+// overrides, the Tenant of the options takes precedence
+func (t *TenantI18n) Translate(
+	languageName string,
+	translateKey string,
+	options ...Options,
+) string {
+	var pickedOptions Options
+	if len(options) > 0 {
+		pickedOptions = options[0]
+	}
+	if pickedOptions.Tenant == "" {
+		pickedOptions.Tenant = t.tenantName
+	}
+	return t.i18n.Translate(languageName, translateKey, pickedOptions)
+}
+
+// T is a shortcut for Translate
+func (t *TenantI18n) T(
+	languageName string,
+	translateKey string,
+	options ...Options,
+) string {
+	return t.Translate(languageName, translateKey, options...)
+}
+
+// NewTemplatingTranslateFunc works like I18n.NewTemplatingTranslateFunc
+// using the tenant overrides
+func (t *TenantI18n) NewTemplatingTranslateFunc() func(args ...interface{}) string {
+	return func(args ...interface{}) string {
+		lang, key, options := templatingTranslateArgs(args)
+		return t.Translate(lang, key, options)
+	}
+}
+
+// NewLangTranslateFunc works like I18n.NewLangTranslateFunc
+// using the tenant overrides
+func (t *TenantI18n) NewLangTranslateFunc(
+	languageName string,
+) func(
+	translateKey string,
+	options ...Options,
+) string {
+	return func(translateKey string, options ...Options) string {
+		return t.Translate(languageName, translateKey, options...)
+	}
+}
@@ -0,0 +1,110 @@
+package goeasyi18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTenantOverrides(t *testing.T) {
+	newI18n := func() *I18n {
+		i18n := NewI18n()
+		i18n.AddLanguage("en", TranslateStrings{
+			{Key: "projects", Default: "Projects"},
+			{Key: "items", One: "One project", Many: "{{.Qty}} projects"},
+			{Key: "english_only", Default: "English only"},
+		})
+		i18n.AddLanguage("es", TranslateStrings{
+			{Key: "projects", Default: "Proyectos"},
+			{Key: "items", One: "Un proyecto", Many: "{{.Qty}} proyectos"},
+		})
+		i18n.AddTenantOverrides("acme", "en", TranslateStrings{
+			{Key: "projects", Default: "Matters"},
+			{Key: "items", One: "One matter", Many: "{{.Qty}} matters"},
+			{Key: "english_only", Default: "English only for acme"},
+		})
+		i18n.AddTenantOverrides("acme", "es", TranslateStrings{
+			{Key: "projects", Default: "Asuntos"},
+		})
+		return i18n
+	}
+
+	t.Run("the overrides should be used per call", func(t *testing.T) {
+		i18n := newI18n()
+
+		tests := []struct {
+			lang     string
+			key      string
+			options  Options
+			expected string
+		}{
+			{"en", "projects", Options{}, "Projects"},
+			{"en", "projects", Options{Tenant: "acme"}, "Matters"},
+			{"en", "projects", Options{Tenant: "other"}, "Projects"},
+			{"en", "items", Options{Tenant: "acme", Count: createPtr(1)}, "One matter"},
+			{"en", "items", Options{Tenant: "acme", Count: createPtr(3), Data: Data{"Qty": 3}}, "3 matters"},
+			{"es", "projects", Options{Tenant: "acme"}, "Asuntos"},
+			// Not overridden, from the base catalog
+			{"es", "items", Options{Tenant: "acme", Count: createPtr(1)}, "Un proyecto"},
+			// From the overrides of the fallback language
+			{"es", "english_only", Options{Tenant: "acme"}, "English only for acme"},
+			{"xx", "projects", Options{Tenant: "acme"}, "Matters"},
+		}
+
+		for _, test := range tests {
+			got := i18n.Translate(test.lang, test.key, test.options)
+			if got != test.expected {
+				t.Errorf("expected %s; got %s", test.expected, got)
+			}
+		}
+	})
+
+	t.Run("the tenant view should use the overrides", func(t *testing.T) {
+		i18n := newI18n()
+		acme := i18n.Tenant("acme")
+
+		if got := acme.T("en", "projects"); got != "Matters" {
+			t.Errorf("expected Matters; got %s", got)
+		}
+		if got := acme.NewLangTranslateFunc("es")("projects"); got != "Asuntos" {
+			t.Errorf("expected Asuntos; got %s", got)
+		}
+		if got := acme.Translate("en", "projects", Options{Tenant: "other"}); got != "Projects" {
+			t.Errorf("expected Projects; got %s", got)
+		}
+
+		got := execI18nTemplate(acme.NewTemplatingTranslateFunc(), `{{ Translate "lang" "en" "key" "items" "count" "2" "Qty" "2" }}`)
+		if got != "2 matters" {
+			t.Errorf("expected 2 matters; got %s", got)
+		}
+	})
+
+	t.Run("the tenant should be taken from the context", func(t *testing.T) {
+		i18n := newI18n()
+		ctx := WithTenant(context.Background(), "acme")
+
+		if TenantFromContext(ctx) != "acme" || TenantFromContext(context.Background()) != "" {
+			t.Errorf("Unexpected result: %v", TenantFromContext(ctx))
+		}
+		if got := i18n.TranslateContext(ctx, "en", "projects"); got != "Matters" {
+			t.Errorf("expected Matters; got %s", got)
+		}
+		if got := i18n.TranslateContext(context.Background(), "en", "projects"); got != "Projects" {
+			t.Errorf("expected Projects; got %s", got)
+		}
+	})
+
+	t.Run("the overrides can be removed", func(t *testing.T) {
+		i18n := newI18n()
+		if !i18n.HasTenantOverrides("acme") {
+			t.Errorf("expected acme to have overrides")
+		}
+
+		i18n.RemoveTenantOverrides("acme")
+		if i18n.HasTenantOverrides("acme") {
+			t.Errorf("expected acme to not have overrides")
+		}
+		if got := i18n.Translate("en", "projects", Options{Tenant: "acme"}); got != "Projects" {
+			t.Errorf("expected Projects; got %s", got)
+		}
+	})
+}
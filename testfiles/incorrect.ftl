@@ -0,0 +1 @@
+incorrect fluent
@@ -0,0 +1 @@
+world = World
@@ -1,45 +1,133 @@
 package goeasyi18n
 
 type TranslateString struct {
-	Key     string
-	Default string
+	Key     string `json:"Key" yaml:"Key"`
+	Context string `json:"Context,omitempty" yaml:"Context,omitempty"` // Optional, like the gettext msgctxt
+	Default string `json:"Default,omitempty" yaml:"Default,omitempty"`
 
 	// For pluralization
-	Zero string // Optional
-	One  string // Optional
-	Two  string // Optional
-	Few  string // Optional
-	Many string // Optional
+	Zero string `json:"Zero,omitempty" yaml:"Zero,omitempty"` // Optional
+	One  string `json:"One,omitempty" yaml:"One,omitempty"`   // Optional
+	Two  string `json:"Two,omitempty" yaml:"Two,omitempty"`   // Optional
+	Few  string `json:"Few,omitempty" yaml:"Few,omitempty"`   // Optional
+	Many string `json:"Many,omitempty" yaml:"Many,omitempty"` // Optional
 
 	// For genders
-	Male      string // Optional
-	Female    string // Optional
-	NonBinary string // Optional
+	Male      string `json:"Male,omitempty" yaml:"Male,omitempty"`           // Optional
+	Female    string `json:"Female,omitempty" yaml:"Female,omitempty"`       // Optional
+	NonBinary string `json:"NonBinary,omitempty" yaml:"NonBinary,omitempty"` // Optional
 
 	// For pluralization with male gender
-	ZeroMale string // Optional
-	OneMale  string // Optional
-	TwoMale  string // Optional
-	FewMale  string // Optional
-	ManyMale string // Optional
+	ZeroMale string `json:"ZeroMale,omitempty" yaml:"ZeroMale,omitempty"` // Optional
+	OneMale  string `json:"OneMale,omitempty" yaml:"OneMale,omitempty"`   // Optional
+	TwoMale  string `json:"TwoMale,omitempty" yaml:"TwoMale,omitempty"`   // Optional
+	FewMale  string `json:"FewMale,omitempty" yaml:"FewMale,omitempty"`   // Optional
+	ManyMale string `json:"ManyMale,omitempty" yaml:"ManyMale,omitempty"` // Optional
 
 	// For pluralization with female gender
-	ZeroFemale string // Optional
-	OneFemale  string // Optional
-	TwoFemale  string // Optional
-	FewFemale  string // Optional
-	ManyFemale string // Optional
+	ZeroFemale string `json:"ZeroFemale,omitempty" yaml:"ZeroFemale,omitempty"` // Optional
+	OneFemale  string `json:"OneFemale,omitempty" yaml:"OneFemale,omitempty"`   // Optional
+	TwoFemale  string `json:"TwoFemale,omitempty" yaml:"TwoFemale,omitempty"`   // Optional
+	FewFemale  string `json:"FewFemale,omitempty" yaml:"FewFemale,omitempty"`   // Optional
+	ManyFemale string `json:"ManyFemale,omitempty" yaml:"ManyFemale,omitempty"` // Optional
 
 	// For pluralization with non binary gender
-	ZeroNonBinary string // Optional
-	OneNonBinary  string // Optional
-	TwoNonBinary  string // Optional
-	FewNonBinary  string // Optional
-	ManyNonBinary string // Optional
+	ZeroNonBinary string `json:"ZeroNonBinary,omitempty" yaml:"ZeroNonBinary,omitempty"` // Optional
+	OneNonBinary  string `json:"OneNonBinary,omitempty" yaml:"OneNonBinary,omitempty"`   // Optional
+	TwoNonBinary  string `json:"TwoNonBinary,omitempty" yaml:"TwoNonBinary,omitempty"`   // Optional
+	FewNonBinary  string `json:"FewNonBinary,omitempty" yaml:"FewNonBinary,omitempty"`   // Optional
+	ManyNonBinary string `json:"ManyNonBinary,omitempty" yaml:"ManyNonBinary,omitempty"` // Optional
+
+	// Metadata for the translators, it is not used to translate
+	Description   string   `json:"Description,omitempty" yaml:"Description,omitempty"`     // Optional
+	MaxLength     int      `json:"MaxLength,omitempty" yaml:"MaxLength,omitempty"`         // Optional, in characters
+	Tags          []string `json:"Tags,omitempty" yaml:"Tags,omitempty"`                   // Optional
+	ScreenshotRef string   `json:"ScreenshotRef,omitempty" yaml:"ScreenshotRef,omitempty"` // Optional, a path or URL
+
+	// Status of the translation, an empty status means that it is not tracked
+	Status TranslationStatus `json:"Status,omitempty" yaml:"Status,omitempty"` // Optional
+	// Hash of the source text that was translated (see HashSourceText)
+	SourceHash string `json:"SourceHash,omitempty" yaml:"SourceHash,omitempty"` // Optional
+
+	// For renamed and deprecated keys
+	AliasOf    string `json:"AliasOf,omitempty" yaml:"AliasOf,omitempty"`       // Optional, the key to be used instead
+	Deprecated bool   `json:"Deprecated,omitempty" yaml:"Deprecated,omitempty"` // Optional, its usage is reported with OnKeyEvent
 }
 
 type TranslateStrings []TranslateString
 
+// TranslationStatus is the review status of a translation
+type TranslationStatus string
+
+const (
+	// StatusFuzzy is a translation that may be wrong, like a machine
+	// translation or a translation whose source text has changed
+	StatusFuzzy TranslationStatus = "fuzzy"
+	// StatusNeedsReview is a translation that must be reviewed
+	StatusNeedsReview TranslationStatus = "needs-review"
+	// StatusApproved is a reviewed translation
+	StatusApproved TranslationStatus = "approved"
+)
+
 type PluralizationFunc func(count int) string
 
 type Data map[string]any
+
+// variantNames are the names of the TranslateString fields
+// that can hold a translation, in declaration order
+var variantNames = []string{
+	"Default",
+	"Zero", "One", "Two", "Few", "Many",
+	"Male", "Female", "NonBinary",
+	"ZeroMale", "OneMale", "TwoMale", "FewMale", "ManyMale",
+	"ZeroFemale", "OneFemale", "TwoFemale", "FewFemale", "ManyFemale",
+	"ZeroNonBinary", "OneNonBinary", "TwoNonBinary", "FewNonBinary", "ManyNonBinary",
+}
+
+// isVariantName checks if the name is one of the TranslateString
+// fields that can hold a translation
+func isVariantName(name string) bool {
+	for _, variantName := range variantNames {
+		if variantName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// extraFieldNames are the names of the TranslateString fields
+// that are not the key or a variant (the context and the metadata)
+var extraFieldNames = []string{
+	"Context", "Description", "MaxLength", "Tags", "ScreenshotRef", "Status", "SourceHash",
+	"AliasOf", "Deprecated",
+}
+
+// isExtraFieldName checks if the name is one of the TranslateString
+// fields that hold the context or the metadata
+func isExtraFieldName(name string) bool {
+	for _, extraFieldName := range extraFieldNames {
+		if extraFieldName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// translationID identifies a translation of a language, the same
+// key can be used with different contexts (separated with the EOT
+// character like in the gettext catalogs)
+func translationID(key string, context string) string {
+	if context == "" {
+		return key
+	}
+	return context + "\x04" + key
+}
+
+// describeKey returns the key (and its context if any)
+// quoted to be used in the messages
+func describeKey(key string, context string) string {
+	if context == "" {
+		return "'" + key + "'"
+	}
+	return "'" + key + "' with the context '" + context + "'"
+}